@@ -4,7 +4,9 @@ go 1.18
 
 require (
 	github.com/centrifuge/go-substrate-rpc-client/v4 v4.0.13
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1
 	github.com/google/gofuzz v1.1.1-0.20200604201612-c04b05f3adfa
+	github.com/stretchr/testify v1.7.2
 )
 
 require (
@@ -20,7 +22,6 @@ require (
 	github.com/mimoo/StrobeGo v0.0.0-20220103164710-9a04d6ca976b // indirect
 	github.com/pierrec/xxHash v0.1.5 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.7.2 // indirect
 	github.com/vedhavyas/go-subkey v1.0.4 // indirect
 	golang.org/x/crypto v0.7.0 // indirect
 	golang.org/x/sys v0.6.0 // indirect
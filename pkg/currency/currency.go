@@ -0,0 +1,390 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// CurrencyID is the Centrifuge currency identifier.
+type CurrencyID struct {
+	IsNative bool
+
+	IsTranche bool
+	AsTranche Tranche
+
+	// IsKSM is deprecated on chain but kept in order to preserve the variant indices.
+	IsKSM bool
+
+	IsAUSD bool
+
+	IsForeignAsset bool
+	AsForeignAsset types.U32
+
+	IsStaking bool
+	AsStaking StakingCurrency
+
+	IsLocalAsset bool
+	AsLocalAsset types.U32
+}
+
+func NewNativeCurrencyID() CurrencyID {
+	return CurrencyID{IsNative: true}
+}
+
+func NewTrancheCurrencyID(poolID types.U64, trancheID [16]types.U8) CurrencyID {
+	return CurrencyID{
+		IsTranche: true,
+		AsTranche: Tranche{
+			PoolID:    poolID,
+			TrancheID: trancheID,
+		},
+	}
+}
+
+func NewAUSDCurrencyID() CurrencyID {
+	return CurrencyID{IsAUSD: true}
+}
+
+func NewForeignAssetCurrencyID(assetID types.U32) CurrencyID {
+	return CurrencyID{
+		IsForeignAsset: true,
+		AsForeignAsset: assetID,
+	}
+}
+
+func NewStakingCurrencyID(stakingCurrency StakingCurrency) CurrencyID {
+	return CurrencyID{
+		IsStaking: true,
+		AsStaking: stakingCurrency,
+	}
+}
+
+func NewLocalAssetCurrencyID(assetID types.U32) CurrencyID {
+	return CurrencyID{
+		IsLocalAsset: true,
+		AsLocalAsset: assetID,
+	}
+}
+
+func (c *CurrencyID) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		c.IsNative = true
+
+		return nil
+	case 1:
+		c.IsTranche = true
+
+		return decoder.Decode(&c.AsTranche)
+	case 2:
+		c.IsKSM = true
+
+		return nil
+	case 3:
+		c.IsAUSD = true
+
+		return nil
+	case 4:
+		c.IsForeignAsset = true
+
+		return decoder.Decode(&c.AsForeignAsset)
+	case 5:
+		c.IsStaking = true
+
+		return decoder.Decode(&c.AsStaking)
+	case 6:
+		c.IsLocalAsset = true
+
+		return decoder.Decode(&c.AsLocalAsset)
+	default:
+		return errors.New("unsupported currency ID")
+	}
+}
+
+func (c CurrencyID) Encode(encoder scale.Encoder) error {
+	switch {
+	case c.IsNative:
+		return encoder.PushByte(0)
+	case c.IsTranche:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(c.AsTranche)
+	case c.IsKSM:
+		return encoder.PushByte(2)
+	case c.IsAUSD:
+		return encoder.PushByte(3)
+	case c.IsForeignAsset:
+		if err := encoder.PushByte(4); err != nil {
+			return err
+		}
+
+		return encoder.Encode(c.AsForeignAsset)
+	case c.IsStaking:
+		if err := encoder.PushByte(5); err != nil {
+			return err
+		}
+
+		return encoder.Encode(c.AsStaking)
+	case c.IsLocalAsset:
+		if err := encoder.PushByte(6); err != nil {
+			return err
+		}
+
+		return encoder.Encode(c.AsLocalAsset)
+	default:
+		return errors.New("unsupported currency ID")
+	}
+}
+
+// String returns the text form of the currency ID, for example `Native`, `AUSD`, `ForeignAsset(1)` or
+// `Tranche(42,0x...)`. It is the inverse of ParseCurrencyID.
+func (c CurrencyID) String() string {
+	switch {
+	case c.IsNative:
+		return "Native"
+	case c.IsTranche:
+		return fmt.Sprintf("Tranche(%d,%s)", c.AsTranche.PoolID, c.AsTranche.TrancheIDHex())
+	case c.IsKSM:
+		return "KSM"
+	case c.IsAUSD:
+		return "AUSD"
+	case c.IsForeignAsset:
+		return fmt.Sprintf("ForeignAsset(%d)", c.AsForeignAsset)
+	case c.IsStaking:
+		return fmt.Sprintf("Staking(%s)", c.AsStaking)
+	case c.IsLocalAsset:
+		return fmt.Sprintf("LocalAsset(%d)", c.AsLocalAsset)
+	default:
+		return "Unknown"
+	}
+}
+
+var (
+	ErrInvalidCurrencyID  = errors.New("invalid currency ID")
+	ErrUnsupportedVariant = errors.New("currency ID variant is not supported")
+)
+
+// ParseCurrencyID parses the text form returned by CurrencyID.String.
+func ParseCurrencyID(s string) (CurrencyID, error) {
+	name, args, hasArgs, err := splitVariant(s)
+
+	if err != nil {
+		return CurrencyID{}, err
+	}
+
+	switch name {
+	case "Native", "KSM", "AUSD":
+		if hasArgs {
+			return CurrencyID{}, fmt.Errorf("%w: %s doesn't take arguments", ErrInvalidCurrencyID, name)
+		}
+
+		return CurrencyID{IsNative: name == "Native", IsKSM: name == "KSM", IsAUSD: name == "AUSD"}, nil
+	case "Tranche":
+		parts := strings.Split(args, ",")
+
+		if !hasArgs || len(parts) != 2 {
+			return CurrencyID{}, fmt.Errorf("%w: expected Tranche(pool,tranche)", ErrInvalidCurrencyID)
+		}
+
+		poolID, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 64)
+
+		if err != nil {
+			return CurrencyID{}, fmt.Errorf("%w: invalid pool ID: %s", ErrInvalidCurrencyID, err)
+		}
+
+		trancheID, err := ParseTrancheID(strings.TrimSpace(parts[1]))
+
+		if err != nil {
+			return CurrencyID{}, err
+		}
+
+		return NewTrancheCurrencyID(types.U64(poolID), trancheID), nil
+	case "ForeignAsset", "LocalAsset":
+		if !hasArgs {
+			return CurrencyID{}, fmt.Errorf("%w: expected %s(id)", ErrInvalidCurrencyID, name)
+		}
+
+		assetID, err := strconv.ParseUint(strings.TrimSpace(args), 10, 32)
+
+		if err != nil {
+			return CurrencyID{}, fmt.Errorf("%w: invalid asset ID: %s", ErrInvalidCurrencyID, err)
+		}
+
+		if name == "ForeignAsset" {
+			return NewForeignAssetCurrencyID(types.U32(assetID)), nil
+		}
+
+		return NewLocalAssetCurrencyID(types.U32(assetID)), nil
+	case "Staking":
+		if !hasArgs || strings.TrimSpace(args) != "BlockRewards" {
+			return CurrencyID{}, fmt.Errorf("%w: expected Staking(BlockRewards)", ErrInvalidCurrencyID)
+		}
+
+		return NewStakingCurrencyID(StakingCurrency{IsBlockRewards: true}), nil
+	default:
+		return CurrencyID{}, fmt.Errorf("%w: unknown variant %q", ErrInvalidCurrencyID, name)
+	}
+}
+
+// splitVariant splits `Name(args)` into its name and arguments.
+func splitVariant(s string) (name, args string, hasArgs bool, err error) {
+	s = strings.TrimSpace(s)
+
+	open := strings.Index(s, "(")
+
+	if open < 0 {
+		return s, "", false, nil
+	}
+
+	if !strings.HasSuffix(s, ")") {
+		return "", "", false, fmt.Errorf("%w: missing closing parenthesis in %q", ErrInvalidCurrencyID, s)
+	}
+
+	return s[:open], s[open+1 : len(s)-1], true, nil
+}
+
+// FromGSRPC converts the generic currency ID of go-substrate-rpc-client.
+func FromGSRPC(c types.CurrencyID) (CurrencyID, error) {
+	switch {
+	case c.IsNative:
+		return NewNativeCurrencyID(), nil
+	case c.IsTranche:
+		return NewTrancheCurrencyID(c.Tranche.FirstVal, c.Tranche.SecondVal), nil
+	case c.IsKSM:
+		return CurrencyID{IsKSM: true}, nil
+	case c.IsAUSD:
+		return NewAUSDCurrencyID(), nil
+	case c.IsForeignAsset:
+		return NewForeignAssetCurrencyID(c.AsForeignAsset), nil
+	case c.IsStaking:
+		return NewStakingCurrencyID(StakingCurrency{IsBlockRewards: c.AsStaking.IsBlockRewards}), nil
+	default:
+		return CurrencyID{}, ErrUnsupportedVariant
+	}
+}
+
+// ToGSRPC converts the currency ID to the generic currency ID of go-substrate-rpc-client,
+// which doesn't support local assets.
+func (c CurrencyID) ToGSRPC() (types.CurrencyID, error) {
+	switch {
+	case c.IsNative:
+		return types.CurrencyID{IsNative: true}, nil
+	case c.IsTranche:
+		return types.CurrencyID{
+			IsTranche: true,
+			Tranche: types.Tranche{
+				FirstVal:  c.AsTranche.PoolID,
+				SecondVal: c.AsTranche.TrancheID,
+			},
+		}, nil
+	case c.IsKSM:
+		return types.CurrencyID{IsKSM: true}, nil
+	case c.IsAUSD:
+		return types.CurrencyID{IsAUSD: true}, nil
+	case c.IsForeignAsset:
+		return types.CurrencyID{IsForeignAsset: true, AsForeignAsset: c.AsForeignAsset}, nil
+	case c.IsStaking:
+		return types.CurrencyID{
+			IsStaking: true,
+			AsStaking: types.StakingCurrency{IsBlockRewards: c.AsStaking.IsBlockRewards},
+		}, nil
+	default:
+		return types.CurrencyID{}, ErrUnsupportedVariant
+	}
+}
+
+// Tranche identifies the token of a pool tranche.
+type Tranche struct {
+	PoolID    types.U64
+	TrancheID [16]types.U8
+}
+
+// TrancheIDHex returns the hex encoded tranche ID.
+func (t Tranche) TrancheIDHex() string {
+	return FormatTrancheID(t.TrancheID)
+}
+
+// FormatTrancheID returns the hex encoded tranche ID.
+func FormatTrancheID(trancheID [16]types.U8) string {
+	b := make([]byte, len(trancheID))
+
+	for i, v := range trancheID {
+		b[i] = byte(v)
+	}
+
+	return codec.HexEncodeToString(b)
+}
+
+// ParseTrancheID parses a hex encoded tranche ID.
+func ParseTrancheID(s string) ([16]types.U8, error) {
+	var trancheID [16]types.U8
+
+	b, err := codec.HexDecodeString(s)
+
+	if err != nil {
+		return trancheID, fmt.Errorf("%w: invalid tranche ID: %s", ErrInvalidCurrencyID, err)
+	}
+
+	if len(b) != len(trancheID) {
+		return trancheID, fmt.Errorf("%w: tranche ID must be %d bytes", ErrInvalidCurrencyID, len(trancheID))
+	}
+
+	for i, v := range b {
+		trancheID[i] = types.U8(v)
+	}
+
+	return trancheID, nil
+}
+
+type StakingCurrency struct {
+	IsBlockRewards bool
+}
+
+func (s *StakingCurrency) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		s.IsBlockRewards = true
+
+		return nil
+	default:
+		return errors.New("unsupported staking currency")
+	}
+}
+
+func (s StakingCurrency) Encode(encoder scale.Encoder) error {
+	switch {
+	case s.IsBlockRewards:
+		return encoder.PushByte(0)
+	default:
+		return errors.New("unsupported staking currency")
+	}
+}
+
+func (s StakingCurrency) String() string {
+	switch {
+	case s.IsBlockRewards:
+		return "BlockRewards"
+	default:
+		return "Unknown"
+	}
+}
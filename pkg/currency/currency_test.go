@@ -0,0 +1,118 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+)
+
+var (
+	testTrancheID = [16]types.U8{0xab, 0xcd, 0xef, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d}
+
+	currencyIDFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(cID *CurrencyID, c fuzz.Continue) {
+			switch c.Intn(7) {
+			case 0:
+				cID.IsNative = true
+			case 1:
+				cID.IsTranche = true
+				c.Fuzz(&cID.AsTranche)
+			case 2:
+				cID.IsKSM = true
+			case 3:
+				cID.IsAUSD = true
+			case 4:
+				cID.IsForeignAsset = true
+				c.Fuzz(&cID.AsForeignAsset)
+			case 5:
+				cID.IsStaking = true
+				cID.AsStaking.IsBlockRewards = true
+			case 6:
+				cID.IsLocalAsset = true
+				c.Fuzz(&cID.AsLocalAsset)
+			}
+		}),
+	}
+)
+
+func TestCurrencyID_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[CurrencyID](t, 1000, currencyIDFuzzOpts...)
+	AssertDecodeNilData[CurrencyID](t)
+}
+
+func TestCurrencyID_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{Input: NewNativeCurrencyID(), Expected: codec.MustHexDecodeString("0x00")},
+		{
+			Input:    NewTrancheCurrencyID(42, testTrancheID),
+			Expected: codec.MustHexDecodeString("0x012a00000000000000abcdef0102030405060708090a0b0c0d"),
+		},
+		{Input: NewAUSDCurrencyID(), Expected: codec.MustHexDecodeString("0x03")},
+		{Input: NewForeignAssetCurrencyID(1), Expected: codec.MustHexDecodeString("0x0401000000")},
+		{
+			Input:    NewStakingCurrencyID(StakingCurrency{IsBlockRewards: true}),
+			Expected: codec.MustHexDecodeString("0x0500"),
+		},
+		{Input: NewLocalAssetCurrencyID(2), Expected: codec.MustHexDecodeString("0x0602000000")},
+	})
+}
+
+func TestCurrencyID_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{Input: codec.MustHexDecodeString("0x00"), Expected: NewNativeCurrencyID()},
+		{
+			Input:    codec.MustHexDecodeString("0x012a00000000000000abcdef0102030405060708090a0b0c0d"),
+			Expected: NewTrancheCurrencyID(42, testTrancheID),
+		},
+		{Input: codec.MustHexDecodeString("0x02"), Expected: CurrencyID{IsKSM: true}},
+		{Input: codec.MustHexDecodeString("0x0602000000"), Expected: NewLocalAssetCurrencyID(2)},
+	})
+}
+
+func TestCurrencyID_String(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var cID CurrencyID
+
+		f := fuzz.New().NilChance(0)
+		for _, opt := range currencyIDFuzzOpts {
+			opt(f)
+		}
+		f.Fuzz(&cID)
+
+		res, err := ParseCurrencyID(cID.String())
+		assert.NoError(t, err)
+		assert.Equal(t, cID, res)
+	}
+
+	assert.Equal(
+		t,
+		"Tranche(42,0xabcdef0102030405060708090a0b0c0d)",
+		NewTrancheCurrencyID(42, testTrancheID).String(),
+	)
+}
+
+func TestParseCurrencyID_Errors(t *testing.T) {
+	for _, s := range []string{"", "Foo", "AUSD(1)", "Tranche(1)", "Tranche(1,0xab)", "ForeignAsset", "LocalAsset(x", "Staking(Foo)"} {
+		_, err := ParseCurrencyID(s)
+		assert.ErrorIs(t, err, ErrInvalidCurrencyID, s)
+	}
+}
+
+func TestCurrencyID_GSRPC(t *testing.T) {
+	cID := NewTrancheCurrencyID(42, testTrancheID)
+
+	gsrpcCurrencyID, err := cID.ToGSRPC()
+	assert.NoError(t, err)
+
+	res, err := FromGSRPC(gsrpcCurrencyID)
+	assert.NoError(t, err)
+	assert.Equal(t, cID, res)
+
+	_, err = NewLocalAssetCurrencyID(1).ToGSRPC()
+	assert.ErrorIs(t, err, ErrUnsupportedVariant)
+}
@@ -1,6 +1,7 @@
 package events
 
 import (
+	"github.com/centrifuge/chain-custom-types/pkg/investments"
 	"github.com/centrifuge/chain-custom-types/pkg/keystore"
 	"github.com/centrifuge/chain-custom-types/pkg/loans"
 	"github.com/centrifuge/chain-custom-types/pkg/permissions"
@@ -41,6 +42,17 @@ type Events struct {
 	Fees_FeeToBurn     []EventFeesFeeToBurn     //nolint:stylecheck,golint
 	Fees_FeeToTreasury []EventFeesFeeToTreasury //nolint:stylecheck,golint
 
+	Investments_InvestOrderUpdated                  []investments.EventInvestmentsInvestOrderUpdated                  //nolint:stylecheck,golint
+	Investments_RedeemOrderUpdated                  []investments.EventInvestmentsRedeemOrderUpdated                  //nolint:stylecheck,golint
+	Investments_InvestOrdersCollected               []investments.EventInvestmentsInvestOrdersCollected               //nolint:stylecheck,golint
+	Investments_RedeemOrdersCollected               []investments.EventInvestmentsRedeemOrdersCollected               //nolint:stylecheck,golint
+	Investments_InvestOrdersInProcessing            []investments.EventInvestmentsInvestOrdersInProcessing            //nolint:stylecheck,golint
+	Investments_RedeemOrdersInProcessing            []investments.EventInvestmentsRedeemOrdersInProcessing            //nolint:stylecheck,golint
+	Investments_InvestOrdersCleared                 []investments.EventInvestmentsInvestOrdersCleared                 //nolint:stylecheck,golint
+	Investments_RedeemOrdersCleared                 []investments.EventInvestmentsRedeemOrdersCleared                 //nolint:stylecheck,golint
+	Investments_InvestCollectedForNonClearedOrderId []investments.EventInvestmentsInvestCollectedForNonClearedOrderID //nolint:stylecheck,golint
+	Investments_RedeemCollectedForNonClearedOrderId []investments.EventInvestmentsRedeemCollectedForNonClearedOrderID //nolint:stylecheck,golint
+
 	Keystore_KeyAdded   []keystore.EventKeystoreKeyAdded   //nolint:stylecheck,golint
 	Keystore_KeyRevoked []keystore.EventKeystoreKeyRevoked //nolint:stylecheck,golint
 	Keystore_DepositSet []keystore.EventKeystoreDepositSet //nolint:stylecheck,golint
@@ -0,0 +1,32 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// Events is embedded next to types.EventRecords, so events that GSRPC already decodes must not be redefined,
+// otherwise their fields are ambiguous and can't be found when decoding.
+func TestEvents_ProxyEventsDecodedByGSRPC(t *testing.T) {
+	type eventRecords struct {
+		types.EventRecords
+		Events
+	}
+
+	for _, name := range []string{
+		"Proxy_ProxyExecuted",
+		"Proxy_PureCreated",
+		"Proxy_Announced",
+		"Proxy_ProxyAdded",
+		"Proxy_ProxyRemoved",
+	} {
+		field, ok := reflect.TypeOf(eventRecords{}).FieldByName(name)
+		assert.True(t, ok, name)
+
+		gsrpcField, _ := reflect.TypeOf(types.EventRecords{}).FieldByName(name)
+		assert.Equal(t, gsrpcField.Type, field.Type, name)
+	}
+}
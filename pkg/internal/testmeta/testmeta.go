@@ -0,0 +1,108 @@
+// Package testmeta builds minimal V14 metadata for tests of call builders and storage keys.
+package testmeta
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Pallet describes a pallet, the names of its calls, in call index order, and its storage entries.
+type Pallet struct {
+	Name    string
+	Index   uint8
+	Calls   []string
+	Storage []Storage
+}
+
+// Storage describes a storage entry whose keys are all hashed with `Blake2_128Concat`.
+// Entries without keys are plain storage values.
+type Storage struct {
+	Name string
+	Keys int
+}
+
+// NewMetadata returns V14 metadata that only contains the calls and storage entries of the provided pallets.
+func NewMetadata(pallets ...Pallet) *types.Metadata {
+	meta := types.Metadata{
+		Version: 14,
+		AsMetadataV14: types.MetadataV14{
+			EfficientLookup: make(map[int64]*types.Si1Type),
+		},
+	}
+
+	for i, pallet := range pallets {
+		variants := make([]types.Si1Variant, 0, len(pallet.Calls))
+
+		for callIndex, call := range pallet.Calls {
+			variants = append(variants, types.Si1Variant{
+				Name:  types.Text(call),
+				Index: types.U8(callIndex),
+			})
+		}
+
+		typeID := int64(i)
+
+		meta.AsMetadataV14.EfficientLookup[typeID] = &types.Si1Type{
+			Def: types.Si1TypeDef{
+				IsVariant: true,
+				Variant: types.Si1TypeDefVariant{
+					Variants: variants,
+				},
+			},
+		}
+
+		meta.AsMetadataV14.Pallets = append(meta.AsMetadataV14.Pallets, types.PalletMetadataV14{
+			Name:       types.Text(pallet.Name),
+			HasStorage: len(pallet.Storage) > 0,
+			Storage:    newStorage(pallet),
+			HasCalls:   true,
+			Calls: types.FunctionMetadataV14{
+				Type: types.NewSi1LookupTypeIDFromUInt(uint64(typeID)),
+			},
+			Index: types.NewU8(pallet.Index),
+		})
+	}
+
+	return &meta
+}
+
+func newStorage(pallet Pallet) types.StorageMetadataV14 {
+	storage := types.StorageMetadataV14{
+		Prefix: types.Text(pallet.Name),
+	}
+
+	for _, entry := range pallet.Storage {
+		item := types.StorageEntryMetadataV14{
+			Name: types.Text(entry.Name),
+		}
+
+		if entry.Keys == 0 {
+			item.Type.IsPlainType = true
+		} else {
+			item.Type.IsMap = true
+
+			for i := 0; i < entry.Keys; i++ {
+				item.Type.AsMap.Hashers = append(item.Type.AsMap.Hashers, types.StorageHasherV10{IsBlake2_128Concat: true})
+			}
+		}
+
+		storage.Items = append(storage.Items, item)
+	}
+
+	return storage
+}
+
+// Blake2_128 returns the hash that precedes a key of a `Blake2_128Concat` map.
+func Blake2_128(b []byte) []byte {
+	hasher, err := hash.NewBlake2b128(nil)
+
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err := hasher.Write(b); err != nil {
+		panic(err)
+	}
+
+	return hasher.Sum(nil)
+}
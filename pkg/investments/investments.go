@@ -0,0 +1,217 @@
+package investments
+
+import (
+	"errors"
+
+	"github.com/centrifuge/chain-custom-types/pkg/pools"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// These are the events for the `investments` pallet.
+
+type EventInvestmentsInvestOrderUpdated struct {
+	Phase        types.Phase
+	InvestmentID InvestmentID
+	SubmittedAt  types.U64
+	Who          types.AccountID
+	Amount       types.U128
+	Topics       []types.Hash
+}
+
+type EventInvestmentsRedeemOrderUpdated struct {
+	Phase        types.Phase
+	InvestmentID InvestmentID
+	SubmittedAt  types.U64
+	Who          types.AccountID
+	Amount       types.U128
+	Topics       []types.Hash
+}
+
+type EventInvestmentsInvestOrdersCollected struct {
+	Phase           types.Phase
+	InvestmentID    InvestmentID
+	Who             types.AccountID
+	ProcessedOrders []types.U64
+	Collection      InvestCollection
+	Outcome         CollectOutcome
+	Topics          []types.Hash
+}
+
+type EventInvestmentsRedeemOrdersCollected struct {
+	Phase           types.Phase
+	InvestmentID    InvestmentID
+	Who             types.AccountID
+	ProcessedOrders []types.U64
+	Collection      RedeemCollection
+	Outcome         CollectOutcome
+	Topics          []types.Hash
+}
+
+type EventInvestmentsInvestOrdersInProcessing struct {
+	Phase        types.Phase
+	InvestmentID InvestmentID
+	OrderID      types.U64
+	TotalOrder   TotalOrder
+	Topics       []types.Hash
+}
+
+type EventInvestmentsRedeemOrdersInProcessing struct {
+	Phase        types.Phase
+	InvestmentID InvestmentID
+	OrderID      types.U64
+	TotalOrder   TotalOrder
+	Topics       []types.Hash
+}
+
+type EventInvestmentsInvestOrdersCleared struct {
+	Phase        types.Phase
+	InvestmentID InvestmentID
+	OrderID      types.U64
+	Fulfillment  FulfillmentWithPrice
+	Topics       []types.Hash
+}
+
+type EventInvestmentsRedeemOrdersCleared struct {
+	Phase        types.Phase
+	InvestmentID InvestmentID
+	OrderID      types.U64
+	Fulfillment  FulfillmentWithPrice
+	Topics       []types.Hash
+}
+
+type EventInvestmentsInvestCollectedForNonClearedOrderID struct {
+	Phase        types.Phase
+	InvestmentID InvestmentID
+	Who          types.AccountID
+	Topics       []types.Hash
+}
+
+type EventInvestmentsRedeemCollectedForNonClearedOrderID struct {
+	Phase        types.Phase
+	InvestmentID InvestmentID
+	Who          types.AccountID
+	Topics       []types.Hash
+}
+
+// InvestmentID identifies the tranche that is invested in.
+type InvestmentID = pools.TrancheCurrency
+
+func NewInvestmentID(poolID types.U64, trancheID [16]types.U8) InvestmentID {
+	return InvestmentID{
+		PoolID:    poolID,
+		TrancheID: trancheID,
+	}
+}
+
+// Order is the invest or redeem order of an account, submitted at the order ID
+// that was active at the time.
+type Order struct {
+	Amount      types.U128
+	SubmittedAt types.U64
+}
+
+type TotalOrder struct {
+	Amount types.U128
+}
+
+// FulfillmentWithPrice holds the fulfilled percentage of the orders of an order ID
+// together with the tranche token price used for the conversion.
+type FulfillmentWithPrice struct {
+	OfAmount types.U64
+	Price    types.U128
+}
+
+type InvestCollection struct {
+	PayoutInvestmentInvest    types.U128
+	RemainingInvestmentInvest types.U128
+}
+
+type RedeemCollection struct {
+	PayoutInvestmentRedeem    types.U128
+	RemainingInvestmentRedeem types.U128
+}
+
+type CollectOutcome struct {
+	IsFullyCollected bool
+
+	IsPartiallyCollected bool
+}
+
+func (c *CollectOutcome) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		c.IsFullyCollected = true
+
+		return nil
+	case 1:
+		c.IsPartiallyCollected = true
+
+		return nil
+	default:
+		return errors.New("unsupported collect outcome")
+	}
+}
+
+func (c CollectOutcome) Encode(encoder scale.Encoder) error {
+	switch {
+	case c.IsFullyCollected:
+		return encoder.PushByte(0)
+	case c.IsPartiallyCollected:
+		return encoder.PushByte(1)
+	default:
+		return errors.New("unsupported collect outcome")
+	}
+}
+
+const (
+	investmentsPrefix = "Investments"
+
+	investOrdersMethodName        = "InvestOrders"
+	redeemOrdersMethodName        = "RedeemOrders"
+	clearedInvestOrdersMethodName = "ClearedInvestOrders"
+	clearedRedeemOrdersMethodName = "ClearedRedeemOrders"
+)
+
+// InvestOrdersStorageKey returns the storage key of the invest order of an account.
+func InvestOrdersStorageKey(meta *types.Metadata, account types.AccountID, investmentID InvestmentID) (types.StorageKey, error) {
+	return createStorageKey(meta, investOrdersMethodName, account, investmentID)
+}
+
+// RedeemOrdersStorageKey returns the storage key of the redeem order of an account.
+func RedeemOrdersStorageKey(meta *types.Metadata, account types.AccountID, investmentID InvestmentID) (types.StorageKey, error) {
+	return createStorageKey(meta, redeemOrdersMethodName, account, investmentID)
+}
+
+// ClearedInvestOrdersStorageKey returns the storage key of the invest fulfillment of an order ID.
+func ClearedInvestOrdersStorageKey(meta *types.Metadata, investmentID InvestmentID, orderID types.U64) (types.StorageKey, error) {
+	return createStorageKey(meta, clearedInvestOrdersMethodName, investmentID, orderID)
+}
+
+// ClearedRedeemOrdersStorageKey returns the storage key of the redeem fulfillment of an order ID.
+func ClearedRedeemOrdersStorageKey(meta *types.Metadata, investmentID InvestmentID, orderID types.U64) (types.StorageKey, error) {
+	return createStorageKey(meta, clearedRedeemOrdersMethodName, investmentID, orderID)
+}
+
+func createStorageKey(meta *types.Metadata, method string, args ...any) (types.StorageKey, error) {
+	encodedArgs := make([][]byte, 0, len(args))
+
+	for _, arg := range args {
+		encodedArg, err := codec.Encode(arg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		encodedArgs = append(encodedArgs, encodedArg)
+	}
+
+	return types.CreateStorageKey(meta, investmentsPrefix, method, encodedArgs...)
+}
@@ -0,0 +1,132 @@
+package investments
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/internal/testmeta"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+)
+
+var (
+	collectOutcomeFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(o *CollectOutcome, c fuzz.Continue) {
+			if c.RandBool() {
+				o.IsFullyCollected = true
+				return
+			}
+
+			o.IsPartiallyCollected = true
+		}),
+	}
+)
+
+func TestCollectOutcome_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[CollectOutcome](t, 100, collectOutcomeFuzzOpts...)
+	AssertDecodeNilData[CollectOutcome](t)
+}
+
+func TestCollectOutcome_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{Input: CollectOutcome{IsFullyCollected: true}, Expected: codec.MustHexDecodeString("0x00")},
+		{Input: CollectOutcome{IsPartiallyCollected: true}, Expected: codec.MustHexDecodeString("0x01")},
+	})
+}
+
+var (
+	testOrder = Order{
+		Amount:      types.NewU128(*big.NewInt(1000)),
+		SubmittedAt: 2,
+	}
+)
+
+func TestOrder_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[Order](t, 1000)
+	AssertDecodeNilData[Order](t)
+	AssertEncodeEmptyObj[Order](t, 24)
+}
+
+func TestOrder_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{
+			Input:    testOrder,
+			Expected: codec.MustHexDecodeString("0xe80300000000000000000000000000000200000000000000"),
+		},
+	})
+}
+
+func TestFulfillmentWithPrice_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[FulfillmentWithPrice](t, 1000)
+	AssertDecodeNilData[FulfillmentWithPrice](t)
+	AssertEncodeEmptyObj[FulfillmentWithPrice](t, 24)
+}
+
+var (
+	testMeta = testmeta.NewMetadata(testmeta.Pallet{
+		Name:  "Investments",
+		Index: 101,
+		Storage: []testmeta.Storage{
+			{Name: "InvestOrders", Keys: 2},
+			{Name: "RedeemOrders", Keys: 2},
+			{Name: "ClearedInvestOrders", Keys: 2},
+			{Name: "ClearedRedeemOrders", Keys: 2},
+		},
+	})
+
+	testInvestmentID = NewInvestmentID(1, [16]types.U8{0xab})
+	// testInvestmentIDEncoded is the encoded testInvestmentID.
+	testInvestmentIDEncoded = codec.MustHexDecodeString("0x0100000000000000ab000000000000000000000000000000")
+)
+
+func TestInvestOrdersStorageKey(t *testing.T) {
+	account := types.AccountID{1}
+
+	key, err := InvestOrdersStorageKey(testMeta, account, testInvestmentID)
+	assert.NoError(t, err)
+
+	// Twox128("Investments") ++ Twox128("InvestOrders"), followed by the Blake2_128Concat account and investment ID.
+	assert.Equal(
+		t,
+		"0x60a783ffa5c7e9569dc372cf945dd89f6823bd7e7166dd9b50cfa1220761ca7f",
+		codec.HexEncodeToString(key[:32]),
+	)
+	assert.Equal(t, testmeta.Blake2_128(account[:]), []byte(key[32:48]))
+	assert.Equal(t, account[:], []byte(key[48:80]))
+	assert.Equal(t, testmeta.Blake2_128(testInvestmentIDEncoded), []byte(key[80:96]))
+	assert.Equal(t, testInvestmentIDEncoded, []byte(key[96:]))
+
+	redeemKey, err := RedeemOrdersStorageKey(testMeta, account, testInvestmentID)
+	assert.NoError(t, err)
+	assert.Equal(t, key[:16], redeemKey[:16])
+	assert.Equal(t, key[32:], redeemKey[32:])
+	assert.NotEqual(t, key, redeemKey)
+}
+
+func TestClearedRedeemOrdersStorageKey(t *testing.T) {
+	encodedOrderID := codec.MustHexDecodeString("0x0200000000000000")
+
+	key, err := ClearedRedeemOrdersStorageKey(testMeta, testInvestmentID, 2)
+	assert.NoError(t, err)
+
+	// Twox128("Investments") ++ Twox128("ClearedRedeemOrders"), followed by the Blake2_128Concat investment ID
+	// and order ID.
+	assert.Equal(
+		t,
+		"0x60a783ffa5c7e9569dc372cf945dd89fe0c771912b188ffec3c779ce573f967c",
+		codec.HexEncodeToString(key[:32]),
+	)
+	assert.Equal(t, testmeta.Blake2_128(testInvestmentIDEncoded), []byte(key[32:48]))
+	assert.Equal(t, testInvestmentIDEncoded, []byte(key[48:72]))
+	assert.Equal(t, testmeta.Blake2_128(encodedOrderID), []byte(key[72:88]))
+	assert.Equal(t, encodedOrderID, []byte(key[88:]))
+
+	investKey, err := ClearedInvestOrdersStorageKey(testMeta, testInvestmentID, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, key[32:], investKey[32:])
+	assert.NotEqual(t, key, investKey)
+}
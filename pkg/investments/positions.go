@@ -0,0 +1,195 @@
+package investments
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/centrifuge/chain-custom-types/pkg/pools"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	// fixedPointOne is the accuracy of both the runtime's `Perquintill` and its fixed point prices.
+	fixedPointOne = big.NewInt(1_000_000_000_000_000_000)
+
+	ErrZeroPrice          = errors.New("tranche token price is zero")
+	ErrMissingFulfillment = errors.New("missing epoch fulfillment")
+)
+
+// EpochFulfillment is the outcome of an executed epoch for a single tranche.
+// OrderID is the order ID that was cleared by the epoch and Price is the tranche token price
+// used for the conversion, as a fixed point number with 18 decimals.
+type EpochFulfillment struct {
+	OrderID  types.U64
+	Solution pools.TrancheSolution
+	Price    types.U128
+}
+
+// Position is the outcome of the order history of an account in a single tranche.
+type Position struct {
+	// CollectedTrancheTokens is the amount of tranche tokens that was received for invest orders.
+	CollectedTrancheTokens types.U128
+	// OutstandingInvest is the amount of currency of the invest order that is not fulfilled yet.
+	OutstandingInvest types.U128
+	// ReturnedCurrency is the amount of currency that was received for redeem orders.
+	ReturnedCurrency types.U128
+	// OutstandingRedeem is the amount of tranche tokens of the redeem order that is not fulfilled yet.
+	OutstandingRedeem types.U128
+}
+
+// PositionCalculator replicates the order collection of the `investments` pallet.
+type PositionCalculator struct {
+	fulfillments map[types.U64]EpochFulfillment
+}
+
+func NewPositionCalculator(fulfillments []EpochFulfillment) *PositionCalculator {
+	fulfillmentMap := make(map[types.U64]EpochFulfillment, len(fulfillments))
+
+	for _, fulfillment := range fulfillments {
+		fulfillmentMap[fulfillment.OrderID] = fulfillment
+	}
+
+	return &PositionCalculator{
+		fulfillments: fulfillmentMap,
+	}
+}
+
+// Calculate returns the position of an account given its invest and redeem order history.
+//
+// Each order in the history holds the order amount set by an `InvestOrderUpdated` or `RedeemOrderUpdated` event.
+// Similarly to the runtime, the outstanding order is collected up to the order ID of the next update
+// before that update replaces the outstanding amount.
+func (p *PositionCalculator) Calculate(investOrders, redeemOrders []Order) (Position, error) {
+	collectedTrancheTokens, outstandingInvest, err := p.collect(investOrders, investOrderKind)
+
+	if err != nil {
+		return Position{}, fmt.Errorf("couldn't collect invest orders: %w", err)
+	}
+
+	returnedCurrency, outstandingRedeem, err := p.collect(redeemOrders, redeemOrderKind)
+
+	if err != nil {
+		return Position{}, fmt.Errorf("couldn't collect redeem orders: %w", err)
+	}
+
+	return Position{
+		CollectedTrancheTokens: types.NewU128(*collectedTrancheTokens),
+		OutstandingInvest:      types.NewU128(*outstandingInvest),
+		ReturnedCurrency:       types.NewU128(*returnedCurrency),
+		OutstandingRedeem:      types.NewU128(*outstandingRedeem),
+	}, nil
+}
+
+// orderKind selects the fulfillment of an epoch and converts the fulfilled amount of an order.
+type orderKind struct {
+	fulfillment func(solution pools.TrancheSolution) types.U64
+	convert     func(price types.U128, fulfilled *big.Int) (*big.Int, error)
+}
+
+var (
+	investOrderKind = orderKind{
+		fulfillment: func(solution pools.TrancheSolution) types.U64 {
+			return solution.InvestFulfillment
+		},
+		// Like the runtime's `price.reciprocal().mul_int(amount)`, the reciprocal is rounded down
+		// before it is applied to the amount.
+		convert: func(price types.U128, fulfilled *big.Int) (*big.Int, error) {
+			if price.Int == nil || price.Sign() == 0 {
+				return nil, ErrZeroPrice
+			}
+
+			reciprocal := new(big.Int).Mul(fixedPointOne, fixedPointOne)
+			reciprocal.Quo(reciprocal, price.Int)
+
+			res := new(big.Int).Mul(fulfilled, reciprocal)
+
+			return res.Quo(res, fixedPointOne), nil
+		},
+	}
+
+	redeemOrderKind = orderKind{
+		fulfillment: func(solution pools.TrancheSolution) types.U64 {
+			return solution.RedeemFulfillment
+		},
+		// A nil or zero price returns no currency, like the runtime's `price.mul_int(amount)`.
+		convert: func(price types.U128, fulfilled *big.Int) (*big.Int, error) {
+			if price.Int == nil {
+				return new(big.Int), nil
+			}
+
+			res := new(big.Int).Mul(fulfilled, price.Int)
+
+			return res.Quo(res, fixedPointOne), nil
+		},
+	}
+)
+
+func (p *PositionCalculator) collect(orders []Order, kind orderKind) (*big.Int, *big.Int, error) {
+	sorted := make([]Order, len(orders))
+	copy(sorted, orders)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].SubmittedAt < sorted[j].SubmittedAt
+	})
+
+	collected := new(big.Int)
+	remaining := new(big.Int)
+
+	for i, order := range sorted {
+		remaining = orderAmount(order)
+
+		// The order is collected up to the next update, or as far as epochs were cleared.
+		hasLimit := i+1 < len(sorted)
+
+		var limit types.U64
+
+		if hasLimit {
+			limit = sorted[i+1].SubmittedAt
+		}
+
+		for orderID := order.SubmittedAt; !hasLimit || orderID < limit; orderID++ {
+			if remaining.Sign() == 0 {
+				break
+			}
+
+			fulfillment, ok := p.fulfillments[orderID]
+
+			if !ok {
+				if hasLimit {
+					return nil, nil, fmt.Errorf("%w for order ID %d", ErrMissingFulfillment, orderID)
+				}
+
+				break
+			}
+
+			fulfilled := perquintillMulFloor(kind.fulfillment(fulfillment.Solution), remaining)
+
+			converted, err := kind.convert(fulfillment.Price, fulfilled)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			collected.Add(collected, converted)
+			remaining = new(big.Int).Sub(remaining, fulfilled)
+		}
+	}
+
+	return collected, remaining, nil
+}
+
+func orderAmount(order Order) *big.Int {
+	if order.Amount.Int == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(order.Amount.Int)
+}
+
+func perquintillMulFloor(percentage types.U64, amount *big.Int) *big.Int {
+	res := new(big.Int).Mul(amount, new(big.Int).SetUint64(uint64(percentage)))
+
+	return res.Quo(res, fixedPointOne)
+}
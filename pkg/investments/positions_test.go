@@ -0,0 +1,128 @@
+package investments
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/pools"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	perquintillHalf = types.U64(500_000_000_000_000_000)
+	perquintillOne  = types.U64(1_000_000_000_000_000_000)
+)
+
+func newU128(v int64) types.U128 {
+	return types.NewU128(*big.NewInt(v))
+}
+
+func price(v int64) types.U128 {
+	return types.NewU128(*new(big.Int).Mul(big.NewInt(v), fixedPointOne))
+}
+
+func TestPositionCalculator_Calculate(t *testing.T) {
+	calculator := NewPositionCalculator([]EpochFulfillment{
+		{
+			OrderID:  1,
+			Solution: pools.TrancheSolution{InvestFulfillment: perquintillHalf, RedeemFulfillment: perquintillOne},
+			Price:    price(2),
+		},
+		{
+			OrderID:  2,
+			Solution: pools.TrancheSolution{InvestFulfillment: perquintillHalf, RedeemFulfillment: perquintillOne},
+			Price:    price(4),
+		},
+	})
+
+	position, err := calculator.Calculate(
+		[]Order{{Amount: newU128(1000), SubmittedAt: 1}},
+		[]Order{{Amount: newU128(10), SubmittedAt: 2}},
+	)
+	assert.NoError(t, err)
+
+	// 500 at a price of 2 and 250 at a price of 4.
+	assert.Equal(t, int64(312), position.CollectedTrancheTokens.Int64())
+	assert.Equal(t, int64(250), position.OutstandingInvest.Int64())
+	assert.Equal(t, int64(40), position.ReturnedCurrency.Int64())
+	assert.Equal(t, int64(0), position.OutstandingRedeem.Int64())
+}
+
+func TestPositionCalculator_Calculate_OrderUpdate(t *testing.T) {
+	calculator := NewPositionCalculator([]EpochFulfillment{
+		{
+			OrderID:  1,
+			Solution: pools.TrancheSolution{InvestFulfillment: perquintillHalf},
+			Price:    price(1),
+		},
+	})
+
+	// The order is collected at order ID 2 before its amount is replaced.
+	position, err := calculator.Calculate(
+		[]Order{
+			{Amount: newU128(1000), SubmittedAt: 1},
+			{Amount: newU128(800), SubmittedAt: 2},
+		},
+		nil,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500), position.CollectedTrancheTokens.Int64())
+	assert.Equal(t, int64(800), position.OutstandingInvest.Int64())
+}
+
+func TestPositionCalculator_Calculate_Errors(t *testing.T) {
+	calculator := NewPositionCalculator([]EpochFulfillment{
+		{
+			OrderID:  1,
+			Solution: pools.TrancheSolution{InvestFulfillment: perquintillHalf},
+		},
+	})
+
+	_, err := calculator.Calculate([]Order{{Amount: newU128(1000), SubmittedAt: 1}}, nil)
+	assert.ErrorIs(t, err, ErrZeroPrice)
+
+	_, err = calculator.Calculate(
+		[]Order{
+			{Amount: newU128(1000), SubmittedAt: 2},
+			{Amount: newU128(800), SubmittedAt: 4},
+		},
+		nil,
+	)
+	assert.ErrorIs(t, err, ErrMissingFulfillment)
+}
+
+func TestPositionCalculator_Calculate_Rounding(t *testing.T) {
+	calculator := NewPositionCalculator([]EpochFulfillment{
+		{
+			OrderID:  1,
+			Solution: pools.TrancheSolution{InvestFulfillment: perquintillOne},
+			Price:    price(3),
+		},
+	})
+
+	// The reciprocal of the price is rounded down before the conversion, like in the runtime.
+	position, err := calculator.Calculate([]Order{{Amount: newU128(3_000_000_000_000_000_000), SubmittedAt: 1}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(999_999_999_999_999_999), position.CollectedTrancheTokens.Int64())
+}
+
+func TestPositionCalculator_Calculate_ZeroRedeemPrice(t *testing.T) {
+	calculator := NewPositionCalculator([]EpochFulfillment{
+		{
+			OrderID:  1,
+			Solution: pools.TrancheSolution{RedeemFulfillment: perquintillHalf},
+		},
+		{
+			OrderID:  2,
+			Solution: pools.TrancheSolution{RedeemFulfillment: perquintillHalf},
+			Price:    newU128(0),
+		},
+	})
+
+	// Redeem orders return no currency for both nil and zero prices.
+	position, err := calculator.Calculate(nil, []Order{{Amount: newU128(1000), SubmittedAt: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), position.ReturnedCurrency.Int64())
+	assert.Equal(t, int64(250), position.OutstandingRedeem.Int64())
+}
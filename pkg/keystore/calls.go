@@ -0,0 +1,41 @@
+package keystore
+
+import (
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	AddKeysCallName    = "Keystore.add_keys"
+	RevokeKeysCallName = "Keystore.revoke_keys"
+	SetDepositCallName = "Keystore.set_deposit"
+)
+
+var (
+	ErrNoKeys = errors.New("no keys provided")
+)
+
+// NewAddKeysCall returns the call that adds the keys to the keystore of the caller.
+func NewAddKeysCall(meta *types.Metadata, keys []AddKey) (types.Call, error) {
+	if len(keys) == 0 {
+		return types.Call{}, ErrNoKeys
+	}
+
+	return types.NewCall(meta, AddKeysCallName, keys)
+}
+
+// NewRevokeKeysCall returns the call that revokes the keys with the provided purpose from the keystore
+// of the caller.
+func NewRevokeKeysCall(meta *types.Metadata, keys []types.Hash, keyPurpose KeyPurpose) (types.Call, error) {
+	if len(keys) == 0 {
+		return types.Call{}, ErrNoKeys
+	}
+
+	return types.NewCall(meta, RevokeKeysCallName, keys, keyPurpose)
+}
+
+// NewSetDepositCall returns the call that sets the deposit reserved for each key, it requires the admin origin.
+func NewSetDepositCall(meta *types.Metadata, newDeposit types.U128) (types.Call, error) {
+	return types.NewCall(meta, SetDepositCallName, newDeposit)
+}
@@ -0,0 +1,47 @@
+package keystore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAddKeysCall(t *testing.T) {
+	call, err := NewAddKeysCall(testMeta, []AddKey{
+		{Key: testKeyHash, Purpose: KeyPurposeP2PDocumentSigning, KeyType: KeyTypeEDDSA},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 104, MethodIndex: 0}, call.CallIndex)
+
+	expectedArgs := append([]byte{0x04}, testKeyHash[:]...)
+	expectedArgs = append(expectedArgs, 0x01, 0x01)
+
+	assert.Equal(t, types.Args(expectedArgs), call.Args)
+
+	_, err = NewAddKeysCall(testMeta, nil)
+	assert.ErrorIs(t, err, ErrNoKeys)
+}
+
+func TestNewRevokeKeysCall(t *testing.T) {
+	call, err := NewRevokeKeysCall(testMeta, []types.Hash{testKeyHash}, KeyPurposeP2PDiscovery)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 104, MethodIndex: 1}, call.CallIndex)
+
+	expectedArgs := append([]byte{0x04}, testKeyHash[:]...)
+	expectedArgs = append(expectedArgs, 0x00)
+
+	assert.Equal(t, types.Args(expectedArgs), call.Args)
+
+	_, err = NewRevokeKeysCall(testMeta, nil, KeyPurposeP2PDiscovery)
+	assert.ErrorIs(t, err, ErrNoKeys)
+}
+
+func TestNewSetDepositCall(t *testing.T) {
+	call, err := NewSetDepositCall(testMeta, types.NewU128(*big.NewInt(100)))
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 104, MethodIndex: 2}, call.CallIndex)
+	assert.Equal(t, types.Args(codec.MustHexDecodeString("0x64000000000000000000000000000000")), call.Args)
+}
@@ -0,0 +1,108 @@
+package keystore
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+var (
+	ErrInvalidPublicKey = errors.New("invalid public key")
+	ErrKeyHashNotFound  = errors.New("key hash not found")
+)
+
+// KeyHash returns the hash under which the public key is stored in the keystore.
+//
+// The hash of an ed25519 key is the 32 byte public key itself. The runtime doesn't derive the hash of a secp256k1
+// key, it stores the hash that is submitted to `add_keys`. This package uses the blake2_256 hash of the 33 byte
+// compressed key, which is also how Substrate derives the account of an ECDSA key, and keys added with another
+// convention are not found by FindKey or the Verifier. Uncompressed 65 byte secp256k1 keys are compressed first.
+func KeyHash(keyType KeyType, publicKey []byte) (types.Hash, error) {
+	switch keyType {
+	case KeyTypeEDDSA:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return types.Hash{}, fmt.Errorf("%w: ed25519 key must be %d bytes", ErrInvalidPublicKey, ed25519.PublicKeySize)
+		}
+
+		return types.NewHash(publicKey), nil
+	case KeyTypeECDSA:
+		compressed, err := compressSecp256k1(publicKey)
+
+		if err != nil {
+			return types.Hash{}, err
+		}
+
+		keyHash, err := blake2_256(compressed)
+
+		if err != nil {
+			return types.Hash{}, err
+		}
+
+		return types.NewHash(keyHash), nil
+	default:
+		return types.Hash{}, fmt.Errorf("%w: unknown key type %d", ErrInvalidPublicKey, keyType)
+	}
+}
+
+func compressSecp256k1(publicKey []byte) ([]byte, error) {
+	switch {
+	case len(publicKey) == secp256k1.PubKeyBytesLenCompressed:
+	case len(publicKey) == secp256k1.PubKeyBytesLenUncompressed && publicKey[0] == secp256k1.PubKeyFormatUncompressed:
+	default:
+		return nil, fmt.Errorf("%w: secp256k1 key must be 33 or 65 bytes", ErrInvalidPublicKey)
+	}
+
+	key, err := secp256k1.ParsePubKey(publicKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPublicKey, err)
+	}
+
+	return key.SerializeCompressed(), nil
+}
+
+// NewEd25519AddKey returns the entry of `Keystore.add_keys` that adds the ed25519 public key for the purpose.
+func NewEd25519AddKey(publicKey ed25519.PublicKey, keyPurpose KeyPurpose) (AddKey, error) {
+	return newAddKey(KeyTypeEDDSA, publicKey, keyPurpose)
+}
+
+// NewSecp256k1AddKey returns the entry of `Keystore.add_keys` that adds the compressed or uncompressed
+// secp256k1 public key for the purpose.
+func NewSecp256k1AddKey(publicKey []byte, keyPurpose KeyPurpose) (AddKey, error) {
+	return newAddKey(KeyTypeECDSA, publicKey, keyPurpose)
+}
+
+func newAddKey(keyType KeyType, publicKey []byte, keyPurpose KeyPurpose) (AddKey, error) {
+	keyHash, err := KeyHash(keyType, publicKey)
+
+	if err != nil {
+		return AddKey{}, err
+	}
+
+	return AddKey{
+		Key:     keyHash,
+		Purpose: keyPurpose,
+		KeyType: keyType,
+	}, nil
+}
+
+// FindKey returns the key among the provided ones that was registered for the public key,
+// matching both the hash and the key type.
+func FindKey(keys []StoredKey, keyType KeyType, publicKey []byte) (StoredKey, error) {
+	keyHash, err := KeyHash(keyType, publicKey)
+
+	if err != nil {
+		return StoredKey{}, err
+	}
+
+	for _, key := range keys {
+		if key.Hash == keyHash && key.Key.KeyType == keyType {
+			return key, nil
+		}
+	}
+
+	return StoredKey{}, ErrKeyHashNotFound
+}
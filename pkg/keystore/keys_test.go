@@ -0,0 +1,87 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyHash_EDDSA(t *testing.T) {
+	publicKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+
+	addKey, err := NewEd25519AddKey(publicKey, KeyPurposeP2PDiscovery)
+	assert.NoError(t, err)
+	assert.Equal(t, AddKey{
+		Key:     types.NewHash(publicKey),
+		Purpose: KeyPurposeP2PDiscovery,
+		KeyType: KeyTypeEDDSA,
+	}, addKey)
+
+	_, err = KeyHash(KeyTypeEDDSA, publicKey[:31])
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+}
+
+func TestKeyHash_ECDSA_GoldenVector(t *testing.T) {
+	// The secp256k1 generator point of SEC 2, hashed with Python's hashlib.blake2b(digest_size=32).
+	keyHash, err := KeyHash(
+		KeyTypeECDSA,
+		codec.MustHexDecodeString("0x0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"0x2975f1d28b92b6e84499b83b0797ef5235553eeb7edaa0cea243c1128c2fe737",
+		codec.HexEncodeToString(keyHash[:]),
+	)
+}
+
+func TestKeyHash_ECDSA(t *testing.T) {
+	privateKey := secp256k1.PrivKeyFromBytes(bytes.Repeat([]byte{2}, 32))
+
+	compressed := privateKey.PubKey().SerializeCompressed()
+
+	keyHash, err := KeyHash(KeyTypeECDSA, compressed)
+	assert.NoError(t, err)
+
+	expected, err := blake2_256(compressed)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewHash(expected), keyHash)
+
+	// Uncompressed keys result in the same hash.
+	addKey, err := NewSecp256k1AddKey(privateKey.PubKey().SerializeUncompressed(), KeyPurposeP2PDocumentSigning)
+	assert.NoError(t, err)
+	assert.Equal(t, AddKey{
+		Key:     keyHash,
+		Purpose: KeyPurposeP2PDocumentSigning,
+		KeyType: KeyTypeECDSA,
+	}, addKey)
+
+	_, err = KeyHash(KeyTypeECDSA, codec.MustHexDecodeString("0x04"+"00"))
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+
+	_, err = KeyHash(KeyTypeECDSA, append([]byte{0x05}, compressed[1:]...))
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+}
+
+func TestFindKey(t *testing.T) {
+	publicKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+
+	keys := []StoredKey{
+		{Hash: testKeyHash, Key: Key{KeyType: KeyTypeEDDSA}},
+		{Hash: types.NewHash(publicKey), Key: Key{KeyType: KeyTypeEDDSA, KeyPurpose: KeyPurposeP2PDocumentSigning}},
+	}
+
+	key, err := FindKey(keys, KeyTypeEDDSA, publicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, keys[1], key)
+
+	keys[1].Key.KeyType = KeyTypeECDSA
+
+	_, err = FindKey(keys, KeyTypeEDDSA, publicKey)
+	assert.ErrorIs(t, err, ErrKeyHashNotFound)
+}
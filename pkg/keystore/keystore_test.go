@@ -1,6 +1,7 @@
 package keystore
 
 import (
+	"github.com/centrifuge/chain-custom-types/pkg/internal/testmeta"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	fuzz "github.com/google/gofuzz"
@@ -11,6 +12,20 @@ import (
 )
 
 var (
+	testMeta = testmeta.NewMetadata(testmeta.Pallet{
+		Name:  "Keystore",
+		Index: 104,
+		Calls: []string{"add_keys", "revoke_keys", "set_deposit"},
+		Storage: []testmeta.Storage{
+			{Name: "Keys", Keys: 2},
+			{Name: "LastKeyByPurpose", Keys: 2},
+			{Name: "KeyDeposit"},
+		},
+	})
+
+	testKeyHash = types.NewHash([]byte{0xab})
+	testOwner   = types.AccountID{1}
+
 	keyPurpose1        = KeyPurposeP2PDiscovery
 	keyPurpose2        = KeyPurposeP2PDocumentSigning
 	keyPurposeFuzzOpts = []FuzzOpt{
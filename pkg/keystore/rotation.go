@@ -0,0 +1,175 @@
+package keystore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	ErrNoDesiredKeys     = errors.New("purpose would be left without a valid key")
+	ErrKeyAlreadyRevoked = errors.New("key was revoked and can't be added again")
+	ErrKeyTypeMismatch   = errors.New("key is registered with another key type")
+	ErrUnknownKeyPurpose = errors.New("unknown key purpose")
+	ErrDuplicateKey      = errors.New("key is desired twice for the same purpose")
+)
+
+// PublicKey is a public key together with its key type.
+type PublicKey struct {
+	KeyType KeyType
+	Key     []byte
+}
+
+// Revocation holds the keys revoked for a purpose with a single `Keystore.revoke_keys` call.
+type Revocation struct {
+	KeyPurpose KeyPurpose
+	Keys       []types.Hash
+}
+
+// RotationPlan holds the keystore changes that replace the current keys of an owner with the desired ones.
+type RotationPlan struct {
+	// Add holds the keys added with a single `Keystore.add_keys` call.
+	Add []AddKey
+	// Revoke holds the keys revoked with a `Keystore.revoke_keys` call per purpose.
+	Revoke []Revocation
+	// Deposit is the deposit that is reserved for the added keys.
+	Deposit types.U128
+}
+
+// IsEmpty returns true if the current keys already match the desired ones.
+func (p RotationPlan) IsEmpty() bool {
+	return len(p.Add) == 0 && len(p.Revoke) == 0
+}
+
+// Calls returns the calls of the plan. Keys are added before any key is revoked, so that every purpose
+// keeps a valid key if the calls are submitted in order, for example in a `Utility.batch_all` call.
+func (p RotationPlan) Calls(meta *types.Metadata) ([]types.Call, error) {
+	var calls []types.Call
+
+	if len(p.Add) > 0 {
+		call, err := NewAddKeysCall(meta, p.Add)
+
+		if err != nil {
+			return nil, err
+		}
+
+		calls = append(calls, call)
+	}
+
+	for _, revocation := range p.Revoke {
+		call, err := NewRevokeKeysCall(meta, revocation.Keys, revocation.KeyPurpose)
+
+		if err != nil {
+			return nil, err
+		}
+
+		calls = append(calls, call)
+	}
+
+	return calls, nil
+}
+
+// PlanRotation returns the plan that replaces the current keys of an owner with the desired public keys.
+//
+// Purposes that are missing from desired are left unchanged, while purposes that are present must hold
+// at least one key. The deposit is the one that is reserved for each new key, see Tracker.Deposit.
+func PlanRotation(
+	current map[KeyID]Key,
+	desired map[KeyPurpose][]PublicKey,
+	deposit types.U128,
+) (RotationPlan, error) {
+	var plan RotationPlan
+
+	for _, keyPurpose := range sortedPurposes(desired) {
+		if _, ok := keyPurposeMap[keyPurpose]; !ok {
+			return RotationPlan{}, fmt.Errorf("%w: %d", ErrUnknownKeyPurpose, keyPurpose)
+		}
+
+		if len(desired[keyPurpose]) == 0 {
+			return RotationPlan{}, fmt.Errorf("%w: %d", ErrNoDesiredKeys, keyPurpose)
+		}
+
+		keep := make(map[types.Hash]struct{})
+
+		for _, publicKey := range desired[keyPurpose] {
+			keyHash, err := KeyHash(publicKey.KeyType, publicKey.Key)
+
+			if err != nil {
+				return RotationPlan{}, err
+			}
+
+			if _, ok := keep[keyHash]; ok {
+				return RotationPlan{}, fmt.Errorf("%w: %s", ErrDuplicateKey, keyHash.Hex())
+			}
+
+			keep[keyHash] = struct{}{}
+
+			key, ok := current[KeyID{Hash: keyHash, KeyPurpose: keyPurpose}]
+
+			switch {
+			case !ok:
+				plan.Add = append(plan.Add, AddKey{Key: keyHash, Purpose: keyPurpose, KeyType: publicKey.KeyType})
+			case isRevoked(key):
+				return RotationPlan{}, fmt.Errorf("%w: %s", ErrKeyAlreadyRevoked, keyHash.Hex())
+			case key.KeyType != publicKey.KeyType:
+				return RotationPlan{}, fmt.Errorf("%w: %s", ErrKeyTypeMismatch, keyHash.Hex())
+			}
+		}
+
+		revocation := Revocation{KeyPurpose: keyPurpose}
+
+		for keyID, key := range current {
+			if keyID.KeyPurpose != keyPurpose || isRevoked(key) {
+				continue
+			}
+
+			if _, ok := keep[keyID.Hash]; !ok {
+				revocation.Keys = append(revocation.Keys, keyID.Hash)
+			}
+		}
+
+		if len(revocation.Keys) > 0 {
+			sort.Slice(revocation.Keys, func(i, j int) bool {
+				return bytes.Compare(revocation.Keys[i][:], revocation.Keys[j][:]) < 0
+			})
+
+			plan.Revoke = append(plan.Revoke, revocation)
+		}
+	}
+
+	plan.Deposit = keysDeposit(deposit, len(plan.Add))
+
+	return plan, nil
+}
+
+func isRevoked(key Key) bool {
+	revoked, _ := key.RevokedAt.Unwrap()
+
+	return revoked
+}
+
+func keysDeposit(deposit types.U128, keyCount int) types.U128 {
+	if deposit.Int == nil {
+		return types.NewU128(*big.NewInt(0))
+	}
+
+	return types.NewU128(*new(big.Int).Mul(deposit.Int, big.NewInt(int64(keyCount))))
+}
+
+func sortedPurposes(desired map[KeyPurpose][]PublicKey) []KeyPurpose {
+	res := make([]KeyPurpose, 0, len(desired))
+
+	for keyPurpose := range desired {
+		res = append(res, keyPurpose)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] < res[j]
+	})
+
+	return res
+}
@@ -0,0 +1,112 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEd25519Key(seed byte) PublicKey {
+	privateKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+
+	return PublicKey{KeyType: KeyTypeEDDSA, Key: privateKey.Public().(ed25519.PublicKey)}
+}
+
+func TestPlanRotation(t *testing.T) {
+	oldKey := testEd25519Key(1)
+	keptKey := testEd25519Key(2)
+	newKey := testEd25519Key(3)
+	revokedKey := testEd25519Key(4)
+
+	current := map[KeyID]Key{
+		{Hash: types.NewHash(oldKey.Key), KeyPurpose: KeyPurposeP2PDocumentSigning}: {
+			KeyPurpose: KeyPurposeP2PDocumentSigning,
+			KeyType:    KeyTypeEDDSA,
+		},
+		{Hash: types.NewHash(keptKey.Key), KeyPurpose: KeyPurposeP2PDiscovery}: {
+			KeyPurpose: KeyPurposeP2PDiscovery,
+			KeyType:    KeyTypeEDDSA,
+		},
+		{Hash: types.NewHash(revokedKey.Key), KeyPurpose: KeyPurposeP2PDocumentSigning}: {
+			KeyPurpose: KeyPurposeP2PDocumentSigning,
+			KeyType:    KeyTypeEDDSA,
+			RevokedAt:  types.NewOption[types.U32](10),
+		},
+	}
+
+	plan, err := PlanRotation(
+		current,
+		map[KeyPurpose][]PublicKey{
+			KeyPurposeP2PDiscovery:       {keptKey},
+			KeyPurposeP2PDocumentSigning: {newKey},
+		},
+		types.NewU128(*big.NewInt(100)),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []AddKey{
+		{Key: types.NewHash(newKey.Key), Purpose: KeyPurposeP2PDocumentSigning, KeyType: KeyTypeEDDSA},
+	}, plan.Add)
+	assert.Equal(t, []Revocation{
+		{KeyPurpose: KeyPurposeP2PDocumentSigning, Keys: []types.Hash{types.NewHash(oldKey.Key)}},
+	}, plan.Revoke)
+	assert.Equal(t, int64(100), plan.Deposit.Int64())
+
+	calls, err := plan.Calls(testMeta)
+	assert.NoError(t, err)
+	assert.Len(t, calls, 2)
+	assert.Equal(t, types.CallIndex{SectionIndex: 104, MethodIndex: 0}, calls[0].CallIndex)
+	assert.Equal(t, types.CallIndex{SectionIndex: 104, MethodIndex: 1}, calls[1].CallIndex)
+
+	// Purposes that are not desired are left unchanged.
+	plan, err = PlanRotation(
+		current,
+		map[KeyPurpose][]PublicKey{KeyPurposeP2PDiscovery: {keptKey}},
+		types.NewU128(*big.NewInt(100)),
+	)
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+	assert.Equal(t, int64(0), plan.Deposit.Int64())
+
+	calls, err = plan.Calls(testMeta)
+	assert.NoError(t, err)
+	assert.Empty(t, calls)
+}
+
+func TestPlanRotation_Errors(t *testing.T) {
+	key := testEd25519Key(1)
+	deposit := types.NewU128(*big.NewInt(100))
+
+	current := map[KeyID]Key{
+		{Hash: types.NewHash(key.Key), KeyPurpose: KeyPurposeP2PDiscovery}: {
+			KeyPurpose: KeyPurposeP2PDiscovery,
+			KeyType:    KeyTypeEDDSA,
+			RevokedAt:  types.NewOption[types.U32](10),
+		},
+		{Hash: types.NewHash(key.Key), KeyPurpose: KeyPurposeP2PDocumentSigning}: {
+			KeyPurpose: KeyPurposeP2PDocumentSigning,
+			KeyType:    KeyTypeECDSA,
+		},
+	}
+
+	_, err := PlanRotation(current, map[KeyPurpose][]PublicKey{KeyPurposeP2PDiscovery: {}}, deposit)
+	assert.ErrorIs(t, err, ErrNoDesiredKeys)
+
+	_, err = PlanRotation(current, map[KeyPurpose][]PublicKey{KeyPurposeP2PDiscovery: {key}}, deposit)
+	assert.ErrorIs(t, err, ErrKeyAlreadyRevoked)
+
+	_, err = PlanRotation(current, map[KeyPurpose][]PublicKey{KeyPurposeP2PDocumentSigning: {key}}, deposit)
+	assert.ErrorIs(t, err, ErrKeyTypeMismatch)
+
+	_, err = PlanRotation(nil, map[KeyPurpose][]PublicKey{KeyPurposeP2PDiscovery: {key, key}}, deposit)
+	assert.ErrorIs(t, err, ErrDuplicateKey)
+
+	_, err = PlanRotation(nil, map[KeyPurpose][]PublicKey{KeyPurpose(5): {key}}, deposit)
+	assert.ErrorIs(t, err, ErrUnknownKeyPurpose)
+
+	_, err = PlanRotation(nil, map[KeyPurpose][]PublicKey{KeyPurposeP2PDiscovery: {{KeyType: KeyTypeEDDSA}}}, deposit)
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+}
@@ -0,0 +1,167 @@
+package keystore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/xxhash"
+)
+
+const (
+	keystorePrefix             = "Keystore"
+	keysMethodName             = "Keys"
+	lastKeyByPurposeMethodName = "LastKeyByPurpose"
+	keyDepositMethodName       = "KeyDeposit"
+
+	// blake2_128Len is the length of the hash that precedes each key of a `Blake2_128Concat` map.
+	blake2_128Len = 16
+)
+
+var (
+	ErrInvalidStorageKey = errors.New("invalid storage key")
+)
+
+// KeysStorageKey returns the storage key of a key in the keystore of the owner.
+func KeysStorageKey(meta *types.Metadata, owner types.AccountID, keyID KeyID) (types.StorageKey, error) {
+	encodedOwner, err := codec.Encode(owner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	encodedKeyID, err := codec.Encode(keyID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreateStorageKey(meta, keystorePrefix, keysMethodName, encodedOwner, encodedKeyID)
+}
+
+// LastKeyByPurposeStorageKey returns the storage key of the last key that the owner added for the purpose.
+func LastKeyByPurposeStorageKey(
+	meta *types.Metadata,
+	owner types.AccountID,
+	keyPurpose KeyPurpose,
+) (types.StorageKey, error) {
+	encodedOwner, err := codec.Encode(owner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	encodedKeyPurpose, err := codec.Encode(keyPurpose)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreateStorageKey(meta, keystorePrefix, lastKeyByPurposeMethodName, encodedOwner, encodedKeyPurpose)
+}
+
+// KeyDepositStorageKey returns the storage key of the deposit that is reserved for each key.
+func KeyDepositStorageKey(meta *types.Metadata) (types.StorageKey, error) {
+	return types.CreateStorageKey(meta, keystorePrefix, keyDepositMethodName)
+}
+
+// DecodeKeysStorageKey returns the owner and key ID of a storage key of the `Keys` map,
+// for example one returned when iterating over the keys of an owner.
+func DecodeKeysStorageKey(key types.StorageKey) (types.AccountID, KeyID, error) {
+	var (
+		owner types.AccountID
+		keyID KeyID
+	)
+
+	rest, err := decodeMapKey(key, keysMethodName, &owner)
+
+	if err != nil {
+		return owner, keyID, err
+	}
+
+	rest, err = decodeBlake2_128ConcatKey(rest, &keyID)
+
+	if err != nil {
+		return owner, keyID, err
+	}
+
+	if len(rest) != 0 {
+		return owner, keyID, fmt.Errorf("%w: trailing bytes", ErrInvalidStorageKey)
+	}
+
+	return owner, keyID, nil
+}
+
+// DecodeLastKeyByPurposeStorageKey returns the owner and key purpose of a storage key of the `LastKeyByPurpose` map.
+func DecodeLastKeyByPurposeStorageKey(key types.StorageKey) (types.AccountID, KeyPurpose, error) {
+	var (
+		owner      types.AccountID
+		keyPurpose KeyPurpose
+	)
+
+	rest, err := decodeMapKey(key, lastKeyByPurposeMethodName, &owner)
+
+	if err != nil {
+		return owner, keyPurpose, err
+	}
+
+	rest, err = decodeBlake2_128ConcatKey(rest, &keyPurpose)
+
+	if err != nil {
+		return owner, keyPurpose, err
+	}
+
+	if len(rest) != 0 {
+		return owner, keyPurpose, fmt.Errorf("%w: trailing bytes", ErrInvalidStorageKey)
+	}
+
+	return owner, keyPurpose, nil
+}
+
+// decodeMapKey checks the prefix of a keystore map key and decodes its first key.
+func decodeMapKey(key types.StorageKey, method string, target any) ([]byte, error) {
+	prefix := append(xxhash.New128([]byte(keystorePrefix)).Sum(nil), xxhash.New128([]byte(method)).Sum(nil)...)
+
+	if !bytes.HasPrefix(key, prefix) {
+		return nil, fmt.Errorf("%w: not a %s %s key", ErrInvalidStorageKey, keystorePrefix, method)
+	}
+
+	return decodeBlake2_128ConcatKey(key[len(prefix):], target)
+}
+
+// decodeBlake2_128ConcatKey decodes a `Blake2_128Concat` key into the target and returns the remaining bytes.
+func decodeBlake2_128ConcatKey(b []byte, target any) ([]byte, error) {
+	if len(b) < blake2_128Len {
+		return nil, fmt.Errorf("%w: missing key hash", ErrInvalidStorageKey)
+	}
+
+	keyHash, rest := b[:blake2_128Len], b[blake2_128Len:]
+
+	reader := bytes.NewReader(rest)
+
+	if err := scale.NewDecoder(reader).Decode(target); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStorageKey, err)
+	}
+
+	encodedKey := rest[:len(rest)-reader.Len()]
+
+	hasher, err := hash.NewBlake2b128(nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := hasher.Write(encodedKey); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(hasher.Sum(nil), keyHash) {
+		return nil, fmt.Errorf("%w: key hash mismatch", ErrInvalidStorageKey)
+	}
+
+	return rest[len(encodedKey):], nil
+}
@@ -0,0 +1,69 @@
+package keystore
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysStorageKey(t *testing.T) {
+	keyID := KeyID{Hash: testKeyHash, KeyPurpose: KeyPurposeP2PDocumentSigning}
+
+	key, err := KeysStorageKey(testMeta, testOwner, keyID)
+	assert.NoError(t, err)
+
+	// Twox128("Keystore") ++ Twox128("Keys"), followed by the Blake2_128Concat owner and key ID.
+	assert.Equal(
+		t,
+		"0xa867ed37f695b809044460329893dfdd9f99a2ce711f3a31b2fc05604c93f179",
+		codec.HexEncodeToString(key[:32]),
+	)
+	assert.Equal(t, testOwner[:], []byte(key[48:80]))
+	assert.Len(t, key, 32+16+32+16+33)
+
+	owner, decodedKeyID, err := DecodeKeysStorageKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, testOwner, owner)
+	assert.Equal(t, keyID, decodedKeyID)
+
+	_, _, err = DecodeKeysStorageKey(append(key[:len(key):len(key)], 0))
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+
+	key[len(key)-1] = byte(KeyPurposeP2PDiscovery)
+
+	_, _, err = DecodeKeysStorageKey(key)
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+
+	lastKey, err := LastKeyByPurposeStorageKey(testMeta, testOwner, KeyPurposeP2PDiscovery)
+	assert.NoError(t, err)
+
+	_, _, err = DecodeKeysStorageKey(lastKey)
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+
+	_, _, err = DecodeKeysStorageKey(key[:40])
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+}
+
+func TestLastKeyByPurposeStorageKey(t *testing.T) {
+	key, err := LastKeyByPurposeStorageKey(testMeta, testOwner, KeyPurposeP2PDocumentSigning)
+	assert.NoError(t, err)
+	assert.Len(t, key, 32+16+32+16+1)
+
+	owner, keyPurpose, err := DecodeLastKeyByPurposeStorageKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, testOwner, owner)
+	assert.Equal(t, KeyPurposeP2PDocumentSigning, keyPurpose)
+
+	_, _, err = DecodeLastKeyByPurposeStorageKey(append(key, 0))
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+}
+
+func TestKeyDepositStorageKey(t *testing.T) {
+	key, err := KeyDepositStorageKey(testMeta)
+	assert.NoError(t, err)
+	assert.Equal(t, types.StorageKey(codec.MustHexDecodeString(
+		"0xa867ed37f695b809044460329893dfdd288d63d94b01ccd87391465dde48c1e3",
+	)), key)
+}
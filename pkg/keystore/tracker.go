@@ -0,0 +1,325 @@
+package keystore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/centrifuge/chain-custom-types/pkg/phase"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	ErrRevocationUnresolved = errors.New("key may have been revoked by an unresolved revocation")
+	ErrUnknownRevocation    = errors.New("unknown revocation")
+	ErrKeyNotFound          = errors.New("key not found")
+)
+
+// TrackedKey is a key of the keystore of an owner together with the block at which it was added.
+type TrackedKey struct {
+	Owner      types.AccountID
+	Hash       types.Hash
+	KeyPurpose KeyPurpose
+	KeyType    KeyType
+	// AddedAt is the block at which the key was added.
+	AddedAt types.U32
+	// RevokedAt is the block at which the key was revoked, it's not set if the key is not revoked.
+	RevokedAt types.Option[types.U32]
+	// Deposit is the deposit that was reserved when the key was added.
+	Deposit types.U128
+}
+
+// ID returns the identifier of the key in the keystore of its owner.
+func (k TrackedKey) ID() KeyID {
+	return KeyID{Hash: k.Hash, KeyPurpose: k.KeyPurpose}
+}
+
+// ValidAt returns true if the key was valid after the provided block was applied.
+// A key is not valid anymore in the block in which it's revoked.
+func (k TrackedKey) ValidAt(block types.U32) bool {
+	if k.AddedAt > block {
+		return false
+	}
+
+	ok, revokedAt := k.RevokedAt.Unwrap()
+
+	return !ok || revokedAt > block
+}
+
+// Tracker keeps the keys of all owners, built from the events of the `keystore` pallet.
+type Tracker struct {
+	keys       map[types.AccountID]map[KeyID]*TrackedKey
+	last       map[types.AccountID]map[KeyPurpose]types.Hash
+	deposit    types.U128
+	unresolved []UnresolvedRevocation
+}
+
+// NewTracker returns a tracker that reserves the provided deposit for keys,
+// until a `DepositSet` event is processed.
+func NewTracker(deposit types.U128) *Tracker {
+	return &Tracker{
+		keys:    make(map[types.AccountID]map[KeyID]*TrackedKey),
+		last:    make(map[types.AccountID]map[KeyPurpose]types.Hash),
+		deposit: deposit,
+	}
+}
+
+// UnresolvedRevocation is a `KeyRevoked` event of a hash that the owner holds for several purposes.
+// The event doesn't hold the purpose, which can be read from the `Keys` storage at the block of the event,
+// see ResolveRevocation.
+type UnresolvedRevocation struct {
+	Owner       types.AccountID
+	Hash        types.Hash
+	BlockNumber types.U32
+}
+
+// ProcessBlock applies the `Keystore_KeyAdded`, `Keystore_KeyRevoked` and `Keystore_DepositSet` events of a block
+// in the order of their phase. Blocks must be processed in order.
+//
+// The `KeyRevoked` event doesn't hold the purpose of the key. If the owner holds the hash for a single purpose,
+// that key is revoked. Otherwise the revocation is kept as unresolved until ResolveRevocation is called,
+// and the validity of these keys is reported as unknown with ErrRevocationUnresolved.
+func (t *Tracker) ProcessBlock(
+	block types.U32,
+	added []EventKeystoreKeyAdded,
+	revoked []EventKeystoreKeyRevoked,
+	depositSet []EventKeystoreDepositSet,
+) {
+	evts := make([]phase.Event, 0, len(added)+len(revoked)+len(depositSet))
+
+	for _, event := range added {
+		event := event
+
+		evts = append(evts, phase.Event{Phase: event.Phase, Apply: func() error {
+			t.AddKey(block, event.Owner, AddKey{Key: event.Key, Purpose: event.KeyPurpose, KeyType: event.KeyType})
+
+			return nil
+		}})
+	}
+
+	for _, event := range revoked {
+		event := event
+
+		evts = append(evts, phase.Event{Phase: event.Phase, Apply: func() error {
+			t.revokeHash(event.Owner, event.Key, event.BlockNumber)
+
+			return nil
+		}})
+	}
+
+	for _, event := range depositSet {
+		event := event
+
+		evts = append(evts, phase.Event{Phase: event.Phase, Apply: func() error {
+			t.deposit = event.NewDeposit
+
+			return nil
+		}})
+	}
+
+	// The keystore events always apply.
+	_ = phase.Apply(evts)
+}
+
+func (t *Tracker) revokeHash(owner types.AccountID, hash types.Hash, block types.U32) {
+	var candidates []KeyID
+
+	for keyID, key := range t.keys[owner] {
+		if key.Hash == hash && key.ValidAt(block) {
+			candidates = append(candidates, keyID)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return
+	case 1:
+		t.RevokeKey(block, owner, candidates[0])
+	default:
+		t.unresolved = append(t.unresolved, UnresolvedRevocation{Owner: owner, Hash: hash, BlockNumber: block})
+	}
+}
+
+// AddKey adds a key to the keystore of the owner. Similarly to the runtime, keys can't be added twice,
+// even after they were revoked.
+func (t *Tracker) AddKey(block types.U32, owner types.AccountID, key AddKey) {
+	keyID := KeyID{Hash: key.Key, KeyPurpose: key.Purpose}
+
+	if _, ok := t.keys[owner][keyID]; ok {
+		return
+	}
+
+	if _, ok := t.keys[owner]; !ok {
+		t.keys[owner] = make(map[KeyID]*TrackedKey)
+		t.last[owner] = make(map[KeyPurpose]types.Hash)
+	}
+
+	t.keys[owner][keyID] = &TrackedKey{
+		Owner:      owner,
+		Hash:       key.Key,
+		KeyPurpose: key.Purpose,
+		KeyType:    key.KeyType,
+		AddedAt:    block,
+		Deposit:    t.deposit,
+	}
+
+	t.last[owner][key.Purpose] = key.Key
+}
+
+// RevokeKey revokes a key of the owner at the provided block. Revoking a key that is unknown
+// or already revoked is a no-op.
+func (t *Tracker) RevokeKey(block types.U32, owner types.AccountID, keyID KeyID) {
+	key, ok := t.keys[owner][keyID]
+
+	if !ok {
+		return
+	}
+
+	if revoked, _ := key.RevokedAt.Unwrap(); revoked {
+		return
+	}
+
+	key.RevokedAt = types.NewOption(block)
+}
+
+// UnresolvedRevocations returns the revocations whose purpose is unknown, in the order of their events.
+func (t *Tracker) UnresolvedRevocations() []UnresolvedRevocation {
+	res := make([]UnresolvedRevocation, len(t.unresolved))
+	copy(res, t.unresolved)
+
+	return res
+}
+
+// ResolveRevocation revokes the key of the revocation for the purpose, usually the one whose `revoked_at`
+// is set to the block of the revocation in the `Keys` storage.
+func (t *Tracker) ResolveRevocation(revocation UnresolvedRevocation, keyPurpose KeyPurpose) error {
+	for i, unresolved := range t.unresolved {
+		if unresolved != revocation {
+			continue
+		}
+
+		keyID := KeyID{Hash: revocation.Hash, KeyPurpose: keyPurpose}
+
+		key, ok := t.keys[revocation.Owner][keyID]
+
+		if !ok || !key.ValidAt(revocation.BlockNumber) {
+			return fmt.Errorf("%w: %s for purpose %d", ErrKeyNotFound, revocation.Hash.Hex(), keyPurpose)
+		}
+
+		t.RevokeKey(revocation.BlockNumber, revocation.Owner, keyID)
+		t.unresolved = append(t.unresolved[:i], t.unresolved[i+1:]...)
+
+		return nil
+	}
+
+	return ErrUnknownRevocation
+}
+
+// isUnresolved returns true if an unresolved revocation of the hash of the key, at or before the provided block,
+// may have revoked it.
+func (t *Tracker) isUnresolved(key TrackedKey, block types.U32) bool {
+	for _, revocation := range t.unresolved {
+		if revocation.Owner == key.Owner &&
+			revocation.Hash == key.Hash &&
+			revocation.BlockNumber <= block &&
+			key.ValidAt(revocation.BlockNumber) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Deposit returns the deposit that is reserved for new keys.
+func (t *Tracker) Deposit() types.U128 {
+	return t.deposit
+}
+
+// Key returns a key of the owner.
+func (t *Tracker) Key(owner types.AccountID, keyID KeyID) (TrackedKey, bool) {
+	key, ok := t.keys[owner][keyID]
+
+	if !ok {
+		return TrackedKey{}, false
+	}
+
+	return *key, true
+}
+
+// LastKeyByPurpose returns the hash of the last key that the owner added for the purpose.
+func (t *Tracker) LastKeyByPurpose(owner types.AccountID, keyPurpose KeyPurpose) (types.Hash, bool) {
+	hash, ok := t.last[owner][keyPurpose]
+
+	return hash, ok
+}
+
+// Keys returns all the keys of the owner, including the revoked ones, sorted by the block at which they were added.
+func (t *Tracker) Keys(owner types.AccountID) []TrackedKey {
+	res := make([]TrackedKey, 0, len(t.keys[owner]))
+
+	for _, key := range t.keys[owner] {
+		res = append(res, *key)
+	}
+
+	sortKeys(res)
+
+	return res
+}
+
+// ValidKeysAt returns the keys of the owner for the purpose that were valid after the provided block was applied.
+// It returns ErrRevocationUnresolved if the validity of one of the keys is unknown.
+func (t *Tracker) ValidKeysAt(owner types.AccountID, keyPurpose KeyPurpose, block types.U32) ([]TrackedKey, error) {
+	var res []TrackedKey
+
+	for _, key := range t.keys[owner] {
+		if key.KeyPurpose != keyPurpose || !key.ValidAt(block) {
+			continue
+		}
+
+		if t.isUnresolved(*key, block) {
+			return nil, fmt.Errorf("%w: %s", ErrRevocationUnresolved, key.Hash.Hex())
+		}
+
+		res = append(res, *key)
+	}
+
+	sortKeys(res)
+
+	return res, nil
+}
+
+// IsValidAt returns true if the key was valid for the purpose of the owner after the provided block was applied.
+// It returns ErrRevocationUnresolved if the key may have been revoked by an unresolved revocation.
+func (t *Tracker) IsValidAt(
+	owner types.AccountID,
+	hash types.Hash,
+	keyPurpose KeyPurpose,
+	block types.U32,
+) (bool, error) {
+	key, ok := t.keys[owner][KeyID{Hash: hash, KeyPurpose: keyPurpose}]
+
+	if !ok || !key.ValidAt(block) {
+		return false, nil
+	}
+
+	if t.isUnresolved(*key, block) {
+		return false, fmt.Errorf("%w: %s", ErrRevocationUnresolved, hash.Hex())
+	}
+
+	return true, nil
+}
+
+func sortKeys(keys []TrackedKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].AddedAt != keys[j].AddedAt {
+			return keys[i].AddedAt < keys[j].AddedAt
+		}
+
+		if keys[i].KeyPurpose != keys[j].KeyPurpose {
+			return keys[i].KeyPurpose < keys[j].KeyPurpose
+		}
+
+		return bytes.Compare(keys[i].Hash[:], keys[j].Hash[:]) < 0
+	})
+}
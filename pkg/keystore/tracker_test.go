@@ -0,0 +1,156 @@
+package keystore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracker_ProcessBlock(t *testing.T) {
+	signingKey := types.NewHash([]byte{0x01})
+	discoveryKey := types.NewHash([]byte{0x02})
+	sharedKey := types.NewHash([]byte{0x03})
+
+	tracker := NewTracker(types.NewU128(*big.NewInt(100)))
+
+	tracker.ProcessBlock(
+		10,
+		[]EventKeystoreKeyAdded{
+			{
+				Phase:      types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1},
+				Owner:      testOwner,
+				Key:        signingKey,
+				KeyPurpose: KeyPurposeP2PDocumentSigning,
+				KeyType:    KeyTypeEDDSA,
+			},
+			{
+				Phase:      types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 3},
+				Owner:      testOwner,
+				Key:        discoveryKey,
+				KeyPurpose: KeyPurposeP2PDiscovery,
+				KeyType:    KeyTypeEDDSA,
+			},
+		},
+		nil,
+		[]EventKeystoreDepositSet{
+			{
+				Phase:      types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 2},
+				NewDeposit: types.NewU128(*big.NewInt(200)),
+			},
+		},
+	)
+
+	key, ok := tracker.Key(testOwner, KeyID{Hash: signingKey, KeyPurpose: KeyPurposeP2PDocumentSigning})
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(10), key.AddedAt)
+	assert.Equal(t, int64(100), key.Deposit.Int64())
+
+	key, ok = tracker.Key(testOwner, KeyID{Hash: discoveryKey, KeyPurpose: KeyPurposeP2PDiscovery})
+	assert.True(t, ok)
+	assert.Equal(t, int64(200), key.Deposit.Int64())
+	assert.Equal(t, int64(200), tracker.Deposit().Int64())
+
+	tracker.ProcessBlock(
+		20,
+		[]EventKeystoreKeyAdded{
+			{Owner: testOwner, Key: sharedKey, KeyPurpose: KeyPurposeP2PDocumentSigning},
+			{Owner: testOwner, Key: sharedKey, KeyPurpose: KeyPurposeP2PDiscovery},
+		},
+		nil,
+		nil,
+	)
+
+	hash, ok := tracker.LastKeyByPurpose(testOwner, KeyPurposeP2PDocumentSigning)
+	assert.True(t, ok)
+	assert.Equal(t, sharedKey, hash)
+
+	tracker.ProcessBlock(
+		30,
+		nil,
+		[]EventKeystoreKeyRevoked{
+			{Owner: testOwner, Key: signingKey, BlockNumber: 30},
+			{Owner: testOwner, Key: sharedKey, BlockNumber: 30},
+		},
+		nil,
+	)
+
+	assertValidAt(t, tracker, signingKey, KeyPurposeP2PDocumentSigning, 9, false)
+	assertValidAt(t, tracker, signingKey, KeyPurposeP2PDocumentSigning, 10, true)
+	assertValidAt(t, tracker, signingKey, KeyPurposeP2PDocumentSigning, 29, true)
+	assertValidAt(t, tracker, signingKey, KeyPurposeP2PDocumentSigning, 30, false)
+	assertValidAt(t, tracker, signingKey, KeyPurposeP2PDiscovery, 20, false)
+	assertValidAt(t, tracker, discoveryKey, KeyPurposeP2PDiscovery, 30, true)
+
+	validKeys, err := tracker.ValidKeysAt(testOwner, KeyPurposeP2PDocumentSigning, 25)
+	assert.NoError(t, err)
+	assert.Len(t, validKeys, 2)
+	assert.Equal(t, signingKey, validKeys[0].Hash)
+	assert.Equal(t, sharedKey, validKeys[1].Hash)
+
+	// The revoked event doesn't hold the purpose, so the validity of the shared key is unknown
+	// until the revocation is resolved.
+	revocation := UnresolvedRevocation{Owner: testOwner, Hash: sharedKey, BlockNumber: 30}
+
+	assert.Equal(t, []UnresolvedRevocation{revocation}, tracker.UnresolvedRevocations())
+
+	assertValidAt(t, tracker, sharedKey, KeyPurposeP2PDocumentSigning, 29, true)
+
+	_, err = tracker.IsValidAt(testOwner, sharedKey, KeyPurposeP2PDiscovery, 30)
+	assert.ErrorIs(t, err, ErrRevocationUnresolved)
+
+	_, err = tracker.ValidKeysAt(testOwner, KeyPurposeP2PDocumentSigning, 30)
+	assert.ErrorIs(t, err, ErrRevocationUnresolved)
+
+	_, err = tracker.KeysAt(testOwner, KeyPurposeP2PDiscovery, 30)
+	assert.ErrorIs(t, err, ErrRevocationUnresolved)
+
+	assert.ErrorIs(t, tracker.ResolveRevocation(revocation, KeyPurpose(2)), ErrKeyNotFound)
+	assert.NoError(t, tracker.ResolveRevocation(revocation, KeyPurposeP2PDocumentSigning))
+	assert.ErrorIs(t, tracker.ResolveRevocation(revocation, KeyPurposeP2PDocumentSigning), ErrUnknownRevocation)
+	assert.Empty(t, tracker.UnresolvedRevocations())
+
+	assertValidAt(t, tracker, sharedKey, KeyPurposeP2PDocumentSigning, 30, false)
+	assertValidAt(t, tracker, sharedKey, KeyPurposeP2PDiscovery, 30, true)
+
+	validKeys, err = tracker.ValidKeysAt(testOwner, KeyPurposeP2PDocumentSigning, 30)
+	assert.NoError(t, err)
+	assert.Empty(t, validKeys)
+
+	assert.Len(t, tracker.Keys(testOwner), 4)
+	assert.Empty(t, tracker.Keys(types.AccountID{2}))
+}
+
+func TestTracker_AddRevokeKey(t *testing.T) {
+	tracker := NewTracker(types.NewU128(*big.NewInt(100)))
+
+	add := AddKey{Key: testKeyHash, Purpose: KeyPurposeP2PDiscovery, KeyType: KeyTypeECDSA}
+	keyID := KeyID{Hash: testKeyHash, KeyPurpose: KeyPurposeP2PDiscovery}
+
+	tracker.RevokeKey(5, testOwner, keyID)
+	tracker.AddKey(10, testOwner, add)
+	tracker.RevokeKey(15, testOwner, keyID)
+	tracker.RevokeKey(20, testOwner, keyID)
+	// Keys can't be added again after they were revoked.
+	tracker.AddKey(25, testOwner, add)
+
+	key, ok := tracker.Key(testOwner, keyID)
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(10), key.AddedAt)
+	assert.Equal(t, types.NewOption[types.U32](15), key.RevokedAt)
+	assert.False(t, key.ValidAt(25))
+}
+
+func assertValidAt(
+	t *testing.T,
+	tracker *Tracker,
+	hash types.Hash,
+	keyPurpose KeyPurpose,
+	block types.U32,
+	expected bool,
+) {
+	valid, err := tracker.IsValidAt(testOwner, hash, keyPurpose, block)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, valid)
+}
@@ -0,0 +1,166 @@
+package keystore
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+const (
+	// ecdsaSignatureLen is the length of a recoverable secp256k1 signature, `r ++ s ++ v`.
+	ecdsaSignatureLen = 65
+)
+
+var (
+	ErrInvalidSignature = errors.New("signature doesn't match any document signing key")
+	ErrKeyRevoked       = errors.New("signature matches a revoked key")
+)
+
+// StoredKey is a key of the keystore of an owner, as stored in the `Keys` map.
+type StoredKey struct {
+	Hash types.Hash
+	Key  Key
+}
+
+// KeySource provides the keys of the keystore.
+type KeySource interface {
+	// KeysAt returns the keys of the owner for the purpose, including the revoked ones,
+	// as they were stored after the provided block was applied.
+	KeysAt(owner types.AccountID, keyPurpose KeyPurpose, block types.U32) ([]StoredKey, error)
+}
+
+// KeysAt implements KeySource. It returns ErrRevocationUnresolved if one of the keys may have been revoked
+// by an unresolved revocation.
+func (t *Tracker) KeysAt(owner types.AccountID, keyPurpose KeyPurpose, block types.U32) ([]StoredKey, error) {
+	var res []StoredKey
+
+	for _, key := range t.Keys(owner) {
+		if key.KeyPurpose != keyPurpose || key.AddedAt > block {
+			continue
+		}
+
+		if t.isUnresolved(key, block) {
+			return nil, fmt.Errorf("%w: %s", ErrRevocationUnresolved, key.Hash.Hex())
+		}
+
+		storedKey := StoredKey{
+			Hash: key.Hash,
+			Key: Key{
+				KeyPurpose: key.KeyPurpose,
+				KeyType:    key.KeyType,
+				Deposit:    key.Deposit,
+			},
+		}
+
+		if ok, revokedAt := key.RevokedAt.Unwrap(); ok && revokedAt <= block {
+			storedKey.Key.RevokedAt = key.RevokedAt
+		}
+
+		res = append(res, storedKey)
+	}
+
+	return res, nil
+}
+
+// Verifier verifies document signatures against the `KeyPurposeP2PDocumentSigning` keys of the signer.
+type Verifier struct {
+	source KeySource
+}
+
+func NewVerifier(source KeySource) *Verifier {
+	return &Verifier{
+		source: source,
+	}
+}
+
+// Verify checks that the signature of the message was made with a document signing key of the signer
+// that was valid at the provided block, and returns that key.
+//
+// EdDSA signatures are ed25519 signatures of the message. ECDSA signatures are recoverable secp256k1 signatures
+// of the blake2_256 hash of the message, similarly to Substrate's ECDSA signatures.
+func (v *Verifier) Verify(message, signature []byte, signer types.AccountID, block types.U32) (StoredKey, error) {
+	keys, err := v.source.KeysAt(signer, KeyPurposeP2PDocumentSigning, block)
+
+	if err != nil {
+		return StoredKey{}, fmt.Errorf("couldn't retrieve keys: %w", err)
+	}
+
+	var revoked bool
+
+	for _, key := range keys {
+		if key.Key.KeyPurpose != KeyPurposeP2PDocumentSigning || !verifySignature(key, message, signature) {
+			continue
+		}
+
+		if ok, revokedAt := key.Key.RevokedAt.Unwrap(); ok && revokedAt <= block {
+			revoked = true
+
+			continue
+		}
+
+		return key, nil
+	}
+
+	if revoked {
+		return StoredKey{}, ErrKeyRevoked
+	}
+
+	return StoredKey{}, ErrInvalidSignature
+}
+
+func verifySignature(key StoredKey, message, signature []byte) bool {
+	switch key.Key.KeyType {
+	case KeyTypeEDDSA:
+		// The hash of an ed25519 key is the public key itself, see KeyHash.
+		return len(signature) == ed25519.SignatureSize && ed25519.Verify(key.Hash[:], message, signature)
+	case KeyTypeECDSA:
+		if len(signature) != ecdsaSignatureLen {
+			return false
+		}
+
+		digest, err := blake2_256(message)
+
+		if err != nil {
+			return false
+		}
+
+		recoveryID := signature[ecdsaSignatureLen-1]
+
+		if recoveryID > 3 {
+			return false
+		}
+
+		// ecdsa.RecoverCompact expects `27 + v + 4 ++ r ++ s`, where 4 selects a compressed key.
+		compact := append([]byte{27 + recoveryID + 4}, signature[:ecdsaSignatureLen-1]...)
+
+		publicKey, _, err := ecdsa.RecoverCompact(compact, digest)
+
+		if err != nil {
+			return false
+		}
+
+		keyHash, err := KeyHash(KeyTypeECDSA, publicKey.SerializeCompressed())
+
+		return err == nil && keyHash == key.Hash
+	default:
+		return false
+	}
+}
+
+func blake2_256(b []byte) ([]byte, error) {
+	hasher, err := hash.NewBlake2b256(nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := hasher.Write(b); err != nil {
+		return nil, err
+	}
+
+	return hasher.Sum(nil), nil
+}
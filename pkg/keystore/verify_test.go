@@ -0,0 +1,115 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testMessage = []byte("document signing root")
+)
+
+func TestVerifier_Verify_EDDSA(t *testing.T) {
+	privateKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize))
+	keyHash := types.NewHash(privateKey.Public().(ed25519.PublicKey))
+
+	tracker := NewTracker(types.NewU128(*big.NewInt(100)))
+	tracker.AddKey(10, testOwner, AddKey{Key: keyHash, Purpose: KeyPurposeP2PDocumentSigning, KeyType: KeyTypeEDDSA})
+	tracker.RevokeKey(20, testOwner, KeyID{Hash: keyHash, KeyPurpose: KeyPurposeP2PDocumentSigning})
+
+	verifier := NewVerifier(tracker)
+	signature := ed25519.Sign(privateKey, testMessage)
+
+	key, err := verifier.Verify(testMessage, signature, testOwner, 15)
+	assert.NoError(t, err)
+	assert.Equal(t, keyHash, key.Hash)
+	assert.Equal(t, types.NewEmptyOption[types.U32](), key.Key.RevokedAt)
+
+	_, err = verifier.Verify(testMessage, signature, testOwner, 9)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	_, err = verifier.Verify(testMessage, signature, testOwner, 20)
+	assert.ErrorIs(t, err, ErrKeyRevoked)
+
+	_, err = verifier.Verify([]byte("other message"), signature, testOwner, 15)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	_, err = verifier.Verify(testMessage, signature, types.AccountID{2}, 15)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+// signECDSA returns the recoverable `r ++ s ++ v` signature of the digest.
+func signECDSA(privateKey *secp256k1.PrivateKey, digest []byte) []byte {
+	compact := ecdsa.SignCompact(privateKey, digest, true)
+
+	return append(compact[1:], compact[0]-27-4)
+}
+
+func TestVerifier_Verify_ECDSA(t *testing.T) {
+	privateKey := secp256k1.PrivKeyFromBytes(bytes.Repeat([]byte{2}, 32))
+
+	keyHash, err := blake2_256(privateKey.PubKey().SerializeCompressed())
+	assert.NoError(t, err)
+
+	tracker := NewTracker(types.NewU128(*big.NewInt(100)))
+	tracker.AddKey(10, testOwner, AddKey{
+		Key:     types.NewHash(keyHash),
+		Purpose: KeyPurposeP2PDocumentSigning,
+		KeyType: KeyTypeECDSA,
+	})
+	// The same key for another purpose doesn't allow signing documents.
+	tracker.AddKey(10, types.AccountID{2}, AddKey{
+		Key:     types.NewHash(keyHash),
+		Purpose: KeyPurposeP2PDiscovery,
+		KeyType: KeyTypeECDSA,
+	})
+
+	digest, err := blake2_256(testMessage)
+	assert.NoError(t, err)
+
+	signature := signECDSA(privateKey, digest)
+
+	verifier := NewVerifier(tracker)
+
+	key, err := verifier.Verify(testMessage, signature, testOwner, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewHash(keyHash), key.Hash)
+	assert.Equal(t, KeyTypeECDSA, key.Key.KeyType)
+
+	_, err = verifier.Verify(testMessage, signature, types.AccountID{2}, 10)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	_, err = verifier.Verify(testMessage, signature[:64], testOwner, 10)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestTracker_KeysAt(t *testing.T) {
+	tracker := NewTracker(types.NewU128(*big.NewInt(100)))
+	tracker.AddKey(10, testOwner, AddKey{Key: testKeyHash, Purpose: KeyPurposeP2PDocumentSigning})
+	tracker.RevokeKey(20, testOwner, KeyID{Hash: testKeyHash, KeyPurpose: KeyPurposeP2PDocumentSigning})
+
+	keys, err := tracker.KeysAt(testOwner, KeyPurposeP2PDocumentSigning, 9)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	keys, err = tracker.KeysAt(testOwner, KeyPurposeP2PDocumentSigning, 19)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, types.NewEmptyOption[types.U32](), keys[0].Key.RevokedAt)
+
+	keys, err = tracker.KeysAt(testOwner, KeyPurposeP2PDocumentSigning, 20)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, types.NewOption[types.U32](20), keys[0].Key.RevokedAt)
+
+	keys, err = tracker.KeysAt(testOwner, KeyPurposeP2PDiscovery, 20)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
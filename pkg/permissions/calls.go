@@ -0,0 +1,139 @@
+package permissions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	AddCallName        = "Permissions.add"
+	RemoveCallName     = "Permissions.remove"
+	PurgeCallName      = "Permissions.purge"
+	AdminPurgeCallName = "Permissions.admin_purge"
+)
+
+var (
+	ErrRoleNotEditable = errors.New("role cannot be edited with the provided role")
+)
+
+// CanEdit returns true if an account holding the editor role is allowed to add or remove the role,
+// following the runtime's role editor rules:
+//
+//   - a PoolAdmin can edit all the pool roles besides TrancheInvestor;
+//   - a MemberListAdmin can edit TrancheInvestor roles;
+//   - a Manager can edit Holder roles.
+//
+// Calls dispatched by the admin origin are not subject to these rules, see NewAdminAddCall and NewAdminRemoveCall.
+func CanEdit(editor Role, role Role) bool {
+	switch {
+	case editor.IsPoolRole && editor.AsPoolRole.IsPoolAdmin:
+		return role.IsPoolRole && !role.AsPoolRole.IsTrancheInvestor
+	case editor.IsPoolRole && editor.AsPoolRole.IsMemberListAdmin:
+		return role.IsPoolRole && role.AsPoolRole.IsTrancheInvestor
+	case editor.IsPermissionedCurrencyRole && editor.AsPermissionedCurrencyRole.IsManager:
+		return role.IsPermissionedCurrencyRole && role.AsPermissionedCurrencyRole.IsHolder
+	default:
+		return false
+	}
+}
+
+func validateEdit(withRole Role, scope PermissionScope, role Role) error {
+	if err := validateScopeRole(scope, role); err != nil {
+		return err
+	}
+
+	if err := validateScopeRole(scope, withRole); err != nil {
+		return fmt.Errorf("invalid editor role: %w", err)
+	}
+
+	if !CanEdit(withRole, role) {
+		return fmt.Errorf("%w: %s cannot edit %s", ErrRoleNotEditable, withRole, role)
+	}
+
+	return nil
+}
+
+// NewAddCall returns the call that grants the role in the scope to the account,
+// using the role of the caller provided in withRole.
+func NewAddCall(
+	meta *types.Metadata,
+	withRole Role,
+	to types.AccountID,
+	scope PermissionScope,
+	role Role,
+) (types.Call, error) {
+	if err := validateEdit(withRole, scope, role); err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, AddCallName, withRole, to, scope, role)
+}
+
+// NewRemoveCall returns the call that revokes the role in the scope from the account,
+// using the role of the caller provided in withRole.
+func NewRemoveCall(
+	meta *types.Metadata,
+	withRole Role,
+	from types.AccountID,
+	scope PermissionScope,
+	role Role,
+) (types.Call, error) {
+	if err := validateEdit(withRole, scope, role); err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, RemoveCallName, withRole, from, scope, role)
+}
+
+// NewAdminAddCall returns the call that grants the role in the scope to the account without checking withRole,
+// which the runtime ignores for calls dispatched by the admin origin.
+func NewAdminAddCall(
+	meta *types.Metadata,
+	withRole Role,
+	to types.AccountID,
+	scope PermissionScope,
+	role Role,
+) (types.Call, error) {
+	if err := validateScopeRole(scope, role); err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, AddCallName, withRole, to, scope, role)
+}
+
+// NewAdminRemoveCall returns the call that revokes the role in the scope from the account without checking withRole,
+// which the runtime ignores for calls dispatched by the admin origin.
+func NewAdminRemoveCall(
+	meta *types.Metadata,
+	withRole Role,
+	from types.AccountID,
+	scope PermissionScope,
+	role Role,
+) (types.Call, error) {
+	if err := validateScopeRole(scope, role); err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, RemoveCallName, withRole, from, scope, role)
+}
+
+// NewPurgeCall returns the call that removes all the roles of the caller in the scope.
+func NewPurgeCall(meta *types.Metadata, scope PermissionScope) (types.Call, error) {
+	if !scope.IsPool && !scope.IsCurrency {
+		return types.Call{}, ErrInvalidScope
+	}
+
+	return types.NewCall(meta, PurgeCallName, scope)
+}
+
+// NewAdminPurgeCall returns the call that removes all the roles of the account in the scope.
+// It must be dispatched by the admin origin.
+func NewAdminPurgeCall(meta *types.Metadata, from types.AccountID, scope PermissionScope) (types.Call, error) {
+	if !scope.IsPool && !scope.IsCurrency {
+		return types.Call{}, ErrInvalidScope
+	}
+
+	return types.NewCall(meta, AdminPurgeCallName, from, scope)
+}
@@ -0,0 +1,124 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanEdit(t *testing.T) {
+	poolAdmin := NewPoolRole(PoolRole{IsPoolAdmin: true})
+	memberListAdmin := NewPoolRole(PoolRole{IsMemberListAdmin: true})
+	manager := NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsManager: true})
+	trancheInvestor := NewTrancheInvestorRole(testTrancheID, 100)
+	holder := NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsHolder: true, AsHolder: 100})
+
+	assert.True(t, CanEdit(poolAdmin, NewPoolRole(PoolRole{IsBorrower: true})))
+	assert.True(t, CanEdit(poolAdmin, poolAdmin))
+	assert.False(t, CanEdit(poolAdmin, trancheInvestor))
+	assert.False(t, CanEdit(poolAdmin, manager))
+
+	assert.True(t, CanEdit(memberListAdmin, trancheInvestor))
+	assert.False(t, CanEdit(memberListAdmin, NewPoolRole(PoolRole{IsBorrower: true})))
+
+	assert.True(t, CanEdit(manager, holder))
+	assert.False(t, CanEdit(manager, NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsIssuer: true})))
+
+	assert.False(t, CanEdit(NewPoolRole(PoolRole{IsLoanAdmin: true}), NewPoolRole(PoolRole{IsBorrower: true})))
+}
+
+func TestNewAddCall(t *testing.T) {
+	call, err := NewAddCall(
+		testMeta,
+		NewPoolRole(PoolRole{IsPoolAdmin: true}),
+		testAccount1,
+		NewPoolScope(42),
+		NewPoolRole(PoolRole{IsBorrower: true}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 98, MethodIndex: 0}, call.CallIndex)
+
+	expectedArgs := append(codec.MustHexDecodeString("0x0000"), testAccount1[:]...)
+	expectedArgs = append(expectedArgs, codec.MustHexDecodeString("0x002a000000000000000001")...)
+
+	assert.Equal(t, types.Args(expectedArgs), call.Args)
+
+	_, err = NewAddCall(
+		testMeta,
+		NewPoolRole(PoolRole{IsPoolAdmin: true}),
+		testAccount1,
+		NewPoolScope(42),
+		NewTrancheInvestorRole(testTrancheID, 100),
+	)
+	assert.ErrorIs(t, err, ErrRoleNotEditable)
+
+	_, err = NewAddCall(
+		testMeta,
+		NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsManager: true}),
+		testAccount1,
+		NewPoolScope(42),
+		NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsHolder: true}),
+	)
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestNewRemoveCall(t *testing.T) {
+	call, err := NewRemoveCall(
+		testMeta,
+		NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsManager: true}),
+		testAccount1,
+		NewCurrencyScope(currency.NewAUSDCurrencyID()),
+		NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsHolder: true, AsHolder: 10}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 98, MethodIndex: 1}, call.CallIndex)
+}
+
+func TestNewAdminCalls(t *testing.T) {
+	// The editor rules don't apply to calls dispatched by the admin origin.
+	call, err := NewAdminAddCall(
+		testMeta,
+		NewPoolRole(PoolRole{IsPoolAdmin: true}),
+		testAccount1,
+		NewPoolScope(42),
+		NewTrancheInvestorRole(testTrancheID, 100),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 98, MethodIndex: 0}, call.CallIndex)
+
+	call, err = NewAdminRemoveCall(
+		testMeta,
+		NewPoolRole(PoolRole{IsPoolAdmin: true}),
+		testAccount1,
+		NewPoolScope(42),
+		NewTrancheInvestorRole(testTrancheID, 100),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 98, MethodIndex: 1}, call.CallIndex)
+
+	_, err = NewAdminAddCall(
+		testMeta,
+		NewPoolRole(PoolRole{IsPoolAdmin: true}),
+		testAccount1,
+		NewPoolScope(42),
+		NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsHolder: true}),
+	)
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestNewPurgeCalls(t *testing.T) {
+	call, err := NewPurgeCall(testMeta, NewPoolScope(42))
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 98, MethodIndex: 2}, call.CallIndex)
+	assert.Equal(t, types.Args(codec.MustHexDecodeString("0x002a00000000000000")), call.Args)
+
+	call, err = NewAdminPurgeCall(testMeta, testAccount1, NewPoolScope(42))
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 98, MethodIndex: 3}, call.CallIndex)
+
+	_, err = NewPurgeCall(testMeta, PermissionScope{})
+	assert.ErrorIs(t, err, ErrInvalidScope)
+}
@@ -0,0 +1,119 @@
+package permissions
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	UtilityBatchAllCallName = "Utility.batch_all"
+)
+
+var (
+	ErrInvalidBatchSize = errors.New("batch size must be positive")
+)
+
+// ExpiringInvestor is a tranche investor permission that expires soon.
+type ExpiringInvestor struct {
+	Account   types.AccountID
+	PoolID    types.U64
+	TrancheID [16]types.U8
+	ExpiresAt types.U64
+}
+
+// ExpiringTrancheInvestors returns the investors of the tranche whose permission is still valid at the provided
+// moment but expires within the window, ordered by expiry.
+func (p *PermissionSet) ExpiringTrancheInvestors(
+	poolID types.U64,
+	trancheID [16]types.U8,
+	now types.U64,
+	window types.U64,
+) []ExpiringInvestor {
+	scope := NewPoolScope(poolID)
+	identity, _ := splitRole(NewTrancheInvestorRole(trancheID, 0))
+
+	var res []ExpiringInvestor
+
+	for key, roles := range p.permissions {
+		if key.Scope != scope {
+			continue
+		}
+
+		moment, ok := roles[identity]
+
+		if !ok || moment < now || moment-now > window {
+			continue
+		}
+
+		res = append(res, ExpiringInvestor{
+			Account:   key.Account,
+			PoolID:    poolID,
+			TrancheID: trancheID,
+			ExpiresAt: moment,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].ExpiresAt != res[j].ExpiresAt {
+			return res[i].ExpiresAt < res[j].ExpiresAt
+		}
+
+		return string(res[i].Account[:]) < string(res[j].Account[:])
+	})
+
+	return res
+}
+
+// NewRenewalBatches returns `Utility.batch_all` calls that extend the permission of the investors until
+// the provided moment, with at most batchSize renewals per batch. The calls use the MemberListAdmin role,
+// which must be held by the caller in the pool of each investor.
+func NewRenewalBatches(
+	meta *types.Metadata,
+	investors []ExpiringInvestor,
+	validUntil types.U64,
+	batchSize int,
+) ([]types.Call, error) {
+	if batchSize <= 0 {
+		return nil, ErrInvalidBatchSize
+	}
+
+	withRole := NewPoolRole(PoolRole{IsMemberListAdmin: true})
+
+	var (
+		batches []types.Call
+		calls   []types.Call
+	)
+
+	for i, investor := range investors {
+		call, err := NewAddCall(
+			meta,
+			withRole,
+			investor.Account,
+			NewPoolScope(investor.PoolID),
+			NewTrancheInvestorRole(investor.TrancheID, validUntil),
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		calls = append(calls, call)
+
+		if len(calls) < batchSize && i < len(investors)-1 {
+			continue
+		}
+
+		batch, err := types.NewCall(meta, UtilityBatchAllCallName, calls)
+
+		if err != nil {
+			return nil, err
+		}
+
+		batches = append(batches, batch)
+		calls = nil
+	}
+
+	return batches, nil
+}
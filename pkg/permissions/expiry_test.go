@@ -0,0 +1,74 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/internal/testmeta"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionSet_ExpiringTrancheInvestors(t *testing.T) {
+	testAccount3 := types.AccountID{3}
+
+	set := NewPermissionSet()
+
+	assert.NoError(t, set.Add(testAccount1, NewPoolScope(7), NewTrancheInvestorRole(testTrancheID, MinDelay+150), 0))
+	assert.NoError(t, set.Add(testAccount2, NewPoolScope(7), NewTrancheInvestorRole(testTrancheID, MinDelay+120), 0))
+	assert.NoError(t, set.Add(testAccount3, NewPoolScope(7), NewTrancheInvestorRole(testTrancheID, MinDelay+500), 0))
+	assert.NoError(t, set.Add(testAccount3, NewPoolScope(8), NewTrancheInvestorRole(testTrancheID, MinDelay+120), 0))
+	assert.NoError(t, set.Add(testAccount3, NewPoolScope(7), NewTrancheInvestorRole([16]types.U8{9}, MinDelay+120), 0))
+
+	assert.Equal(
+		t,
+		[]ExpiringInvestor{
+			{Account: testAccount2, PoolID: 7, TrancheID: testTrancheID, ExpiresAt: MinDelay + 120},
+			{Account: testAccount1, PoolID: 7, TrancheID: testTrancheID, ExpiresAt: MinDelay + 150},
+		},
+		set.ExpiringTrancheInvestors(7, testTrancheID, MinDelay+100, 100),
+	)
+
+	// Expired permissions are not included.
+	assert.Equal(
+		t,
+		[]ExpiringInvestor{
+			{Account: testAccount1, PoolID: 7, TrancheID: testTrancheID, ExpiresAt: MinDelay + 150},
+		},
+		set.ExpiringTrancheInvestors(7, testTrancheID, MinDelay+130, 100),
+	)
+}
+
+func TestNewRenewalBatches(t *testing.T) {
+	meta := testmeta.NewMetadata(
+		testmeta.Pallet{Name: "Utility", Index: 91, Calls: []string{"batch", "as_derivative", "batch_all"}},
+		testmeta.Pallet{Name: "Permissions", Index: 98, Calls: []string{"add", "remove", "purge", "admin_purge"}},
+	)
+
+	investors := []ExpiringInvestor{
+		{Account: testAccount1, PoolID: 7, TrancheID: testTrancheID, ExpiresAt: 120},
+		{Account: testAccount2, PoolID: 7, TrancheID: testTrancheID, ExpiresAt: 150},
+		{Account: testAccount2, PoolID: 8, TrancheID: testTrancheID, ExpiresAt: 150},
+	}
+
+	batches, err := NewRenewalBatches(meta, investors, 1000, 2)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Equal(t, types.CallIndex{SectionIndex: 91, MethodIndex: 2}, batches[0].CallIndex)
+
+	renewal, err := NewAddCall(
+		meta,
+		NewPoolRole(PoolRole{IsMemberListAdmin: true}),
+		testAccount2,
+		NewPoolScope(8),
+		NewTrancheInvestorRole(testTrancheID, 1000),
+	)
+	assert.NoError(t, err)
+
+	encodedRenewal, err := codec.Encode([]types.Call{renewal})
+	assert.NoError(t, err)
+	assert.Equal(t, types.Args(encodedRenewal), batches[1].Args)
+
+	_, err = NewRenewalBatches(meta, investors, 1000, 0)
+	assert.ErrorIs(t, err, ErrInvalidBatchSize)
+}
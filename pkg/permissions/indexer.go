@@ -0,0 +1,234 @@
+package permissions
+
+import (
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// HistoryEntry is a role that was held by an account in a scope between two blocks.
+type HistoryEntry struct {
+	Account types.AccountID
+	Scope   PermissionScope
+	Role    Role
+	// AddedAt is the block at which the role was added.
+	AddedAt types.U32
+	// RemovedAt is the block at which the role was removed, it's not set if the role is still held.
+	RemovedAt types.Option[types.U32]
+}
+
+// heldAt returns true if the role was held after the provided block was applied.
+func (h HistoryEntry) heldAt(block types.U32) bool {
+	if h.AddedAt > block {
+		return false
+	}
+
+	ok, removedAt := h.RemovedAt.Unwrap()
+
+	return !ok || removedAt > block
+}
+
+// Indexer keeps the current permission state and its history, built from the events of the `permissions` pallet.
+type Indexer struct {
+	current *PermissionSet
+	history []HistoryEntry
+	// open maps the account, scope and role identity to the entry in history that is not removed yet.
+	open map[permissionKey]map[Role]int
+}
+
+func NewIndexer() *Indexer {
+	return &Indexer{
+		current: NewPermissionSet(),
+		open:    make(map[permissionKey]map[Role]int),
+	}
+}
+
+// Current returns the permission state after the last processed block.
+func (i *Indexer) Current() *PermissionSet {
+	return i.current
+}
+
+// Event is a `permissions` event of a block together with its index in the events of the block.
+type Event struct {
+	Index types.U32
+
+	IsAdded bool
+	AsAdded EventPermissionsAdded
+
+	IsRemoved bool
+	AsRemoved EventPermissionsRemoved
+
+	IsPurged bool
+	AsPurged EventPermissionsPurged
+}
+
+func NewAddedEvent(index types.U32, event EventPermissionsAdded) Event {
+	return Event{Index: index, IsAdded: true, AsAdded: event}
+}
+
+func NewRemovedEvent(index types.U32, event EventPermissionsRemoved) Event {
+	return Event{Index: index, IsRemoved: true, AsRemoved: event}
+}
+
+func NewPurgedEvent(index types.U32, event EventPermissionsPurged) Event {
+	return Event{Index: index, IsPurged: true, AsPurged: event}
+}
+
+// ProcessBlock applies the `permissions` events of a block in the order of their index, so that a role that is
+// removed and added again in the same extrinsic ends up held. The moment of the block, in seconds, sets the
+// expiry of TrancheInvestor and Holder roles, see PermissionSet.Add. Blocks must be processed in order.
+func (i *Indexer) ProcessBlock(block types.U32, now types.U64, events []Event) {
+	sorted := make([]Event, len(events))
+	copy(sorted, events)
+
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].Index < sorted[b].Index
+	})
+
+	for _, event := range sorted {
+		switch {
+		case event.IsAdded:
+			i.add(block, now, event.AsAdded.To, event.AsAdded.Scope, event.AsAdded.Role)
+		case event.IsRemoved:
+			i.remove(block, now, event.AsRemoved.To, event.AsRemoved.Scope, event.AsRemoved.Role)
+		case event.IsPurged:
+			i.purge(block, event.AsPurged.From, event.AsPurged.Scope)
+		}
+	}
+}
+
+func (i *Indexer) add(block types.U32, now types.U64, account types.AccountID, scope PermissionScope, role Role) {
+	if err := i.current.Add(account, scope, role, now); err != nil {
+		return
+	}
+
+	identity, _ := splitRole(role)
+
+	i.closeEntry(block, permissionKey{account, scope}, identity)
+	i.openStoredEntry(block, account, scope, identity)
+}
+
+func (i *Indexer) remove(block types.U32, now types.U64, account types.AccountID, scope PermissionScope, role Role) {
+	if err := i.current.Remove(account, scope, role, now); err != nil {
+		return
+	}
+
+	identity, _ := splitRole(role)
+
+	i.closeEntry(block, permissionKey{account, scope}, identity)
+
+	// Roles that carry a moment remain held until their new expiry.
+	if isTimeBound(identity) {
+		i.openStoredEntry(block, account, scope, identity)
+	}
+}
+
+// openStoredEntry opens an entry for the role as stored in the current state, with its expiry.
+func (i *Indexer) openStoredEntry(block types.U32, account types.AccountID, scope PermissionScope, identity Role) {
+	if role, ok := i.current.role(account, scope, identity); ok {
+		i.openEntry(block, account, scope, role)
+	}
+}
+
+func (i *Indexer) purge(block types.U32, account types.AccountID, scope PermissionScope) {
+	i.current.Purge(account, scope)
+
+	key := permissionKey{account, scope}
+
+	for identity := range i.open[key] {
+		i.closeEntry(block, key, identity)
+	}
+}
+
+func (i *Indexer) openEntry(block types.U32, account types.AccountID, scope PermissionScope, role Role) {
+	key := permissionKey{account, scope}
+
+	if _, ok := i.open[key]; !ok {
+		i.open[key] = make(map[Role]int)
+	}
+
+	identity, _ := splitRole(role)
+
+	i.open[key][identity] = len(i.history)
+
+	i.history = append(i.history, HistoryEntry{
+		Account: account,
+		Scope:   scope,
+		Role:    role,
+		AddedAt: block,
+	})
+}
+
+func (i *Indexer) closeEntry(block types.U32, key permissionKey, identity Role) {
+	index, ok := i.open[key][identity]
+
+	if !ok {
+		return
+	}
+
+	i.history[index].RemovedAt = types.NewOption(block)
+
+	delete(i.open[key], identity)
+
+	if len(i.open[key]) == 0 {
+		delete(i.open, key)
+	}
+}
+
+// History returns all the roles that were ever held, in the order in which they were added.
+func (i *Indexer) History() []HistoryEntry {
+	res := make([]HistoryEntry, len(i.history))
+
+	copy(res, i.history)
+
+	return res
+}
+
+// HoldersAt returns the accounts that held the role in the scope after the provided block was applied.
+// The moment of a TrancheInvestor or Holder role is ignored when matching, but like PermissionSet.Has,
+// accounts whose role expired before now are left out.
+func (i *Indexer) HoldersAt(scope PermissionScope, role Role, block types.U32, now types.U64) []types.AccountID {
+	identity, _ := splitRole(role)
+
+	seen := make(map[types.AccountID]struct{})
+
+	var res []types.AccountID
+
+	for _, entry := range i.history {
+		if entry.Scope != scope || !entry.heldAt(block) {
+			continue
+		}
+
+		entryIdentity, moment := splitRole(entry.Role)
+
+		if entryIdentity != identity || (isTimeBound(identity) && moment < now) {
+			continue
+		}
+
+		if _, ok := seen[entry.Account]; ok {
+			continue
+		}
+
+		seen[entry.Account] = struct{}{}
+
+		res = append(res, entry.Account)
+	}
+
+	return res
+}
+
+// RolesAt returns the roles held by the account in the scope after the provided block was applied,
+// including expired ones.
+func (i *Indexer) RolesAt(account types.AccountID, scope PermissionScope, block types.U32) []Role {
+	var res []Role
+
+	for _, entry := range i.history {
+		if entry.Account == account && entry.Scope == scope && entry.heldAt(block) {
+			res = append(res, entry.Role)
+		}
+	}
+
+	sortRoles(res)
+
+	return res
+}
@@ -0,0 +1,100 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexer_HoldersAt(t *testing.T) {
+	poolAdmin := NewPoolRole(PoolRole{IsPoolAdmin: true})
+	scope := NewPoolScope(3)
+
+	indexer := NewIndexer()
+
+	indexer.ProcessBlock(10, 0, []Event{
+		NewAddedEvent(1, EventPermissionsAdded{To: testAccount1, Scope: scope, Role: poolAdmin}),
+	})
+	indexer.ProcessBlock(20, 0, []Event{
+		NewAddedEvent(4, EventPermissionsAdded{To: testAccount2, Scope: scope, Role: poolAdmin}),
+		NewRemovedEvent(2, EventPermissionsRemoved{To: testAccount1, Scope: scope, Role: poolAdmin}),
+	})
+	indexer.ProcessBlock(30, 0, []Event{
+		NewPurgedEvent(1, EventPermissionsPurged{From: testAccount2, Scope: scope}),
+	})
+
+	assert.Empty(t, indexer.HoldersAt(scope, poolAdmin, 9, 0))
+	assert.Equal(t, []types.AccountID{testAccount1}, indexer.HoldersAt(scope, poolAdmin, 10, 0))
+	assert.Equal(t, []types.AccountID{testAccount1}, indexer.HoldersAt(scope, poolAdmin, 19, 0))
+	assert.Equal(t, []types.AccountID{testAccount2}, indexer.HoldersAt(scope, poolAdmin, 20, 0))
+	assert.Empty(t, indexer.HoldersAt(scope, poolAdmin, 30, 0))
+	assert.Empty(t, indexer.HoldersAt(NewPoolScope(4), poolAdmin, 10, 0))
+
+	assert.Empty(t, indexer.Current().Entries())
+	assert.Len(t, indexer.History(), 2)
+}
+
+func TestIndexer_ProcessBlock_Order(t *testing.T) {
+	poolAdmin := NewPoolRole(PoolRole{IsPoolAdmin: true})
+	scope := NewPoolScope(3)
+
+	indexer := NewIndexer()
+
+	indexer.ProcessBlock(10, 0, []Event{
+		NewAddedEvent(1, EventPermissionsAdded{To: testAccount1, Scope: scope, Role: poolAdmin}),
+	})
+
+	// The role is removed and added again in the same extrinsic.
+	indexer.ProcessBlock(20, 0, []Event{
+		NewAddedEvent(3, EventPermissionsAdded{To: testAccount1, Scope: scope, Role: poolAdmin}),
+		NewRemovedEvent(2, EventPermissionsRemoved{To: testAccount1, Scope: scope, Role: poolAdmin}),
+	})
+
+	assert.True(t, indexer.Current().Has(testAccount1, scope, poolAdmin, 0))
+	assert.Equal(t, []types.AccountID{testAccount1}, indexer.HoldersAt(scope, poolAdmin, 20, 0))
+
+	// The role is added and removed again in the same extrinsic.
+	indexer.ProcessBlock(30, 0, []Event{
+		NewRemovedEvent(2, EventPermissionsRemoved{To: testAccount1, Scope: scope, Role: poolAdmin}),
+		NewAddedEvent(1, EventPermissionsAdded{To: testAccount1, Scope: scope, Role: poolAdmin}),
+	})
+
+	assert.False(t, indexer.Current().Has(testAccount1, scope, poolAdmin, 0))
+	assert.Empty(t, indexer.HoldersAt(scope, poolAdmin, 30, 0))
+}
+
+func TestIndexer_TrancheInvestor(t *testing.T) {
+	scope := NewPoolScope(3)
+	trancheInvestor := NewTrancheInvestorRole(testTrancheID, 0)
+
+	// The role is added until a moment and removed with a delay, see PermissionSet.Remove.
+	expiry := 3 * MinDelay
+	removedAt := types.U64(100)
+	added := NewTrancheInvestorRole(testTrancheID, expiry)
+	removed := NewTrancheInvestorRole(testTrancheID, 0)
+
+	indexer := NewIndexer()
+
+	indexer.ProcessBlock(10, 0, []Event{
+		NewAddedEvent(0, EventPermissionsAdded{To: testAccount1, Scope: scope, Role: added}),
+	})
+	indexer.ProcessBlock(20, removedAt, []Event{
+		NewRemovedEvent(0, EventPermissionsRemoved{To: testAccount1, Scope: scope, Role: removed}),
+	})
+
+	assert.Equal(t, []Role{added}, indexer.RolesAt(testAccount1, scope, 15))
+	assert.Equal(
+		t,
+		[]Role{NewTrancheInvestorRole(testTrancheID, removedAt+MinDelay)},
+		indexer.RolesAt(testAccount1, scope, 20),
+	)
+	assert.True(t, indexer.Current().IsTrancheInvestor(testAccount1, 3, testTrancheID, removedAt+MinDelay))
+	assert.False(t, indexer.Current().IsTrancheInvestor(testAccount1, 3, testTrancheID, removedAt+MinDelay+1))
+
+	// Expired tranche investors are not holders.
+	assert.Equal(t, []types.AccountID{testAccount1}, indexer.HoldersAt(scope, trancheInvestor, 15, expiry))
+	assert.Empty(t, indexer.HoldersAt(scope, trancheInvestor, 15, expiry+1))
+	assert.Equal(t, []types.AccountID{testAccount1}, indexer.HoldersAt(scope, trancheInvestor, 20, removedAt+MinDelay))
+	assert.Empty(t, indexer.HoldersAt(scope, trancheInvestor, 20, removedAt+MinDelay+1))
+}
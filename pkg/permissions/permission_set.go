@@ -0,0 +1,318 @@
+package permissions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var (
+	ErrInvalidScope = errors.New("invalid permission scope")
+	ErrInvalidRole  = errors.New("invalid role")
+	ErrRoleNotFound = errors.New("role not found")
+	ErrRoleExpired  = errors.New("role expired")
+)
+
+// MinDelay is the minimum validity in seconds that the runtime grants to a TrancheInvestor or Holder role
+// when it's added or removed.
+const MinDelay types.U64 = 7 * 24 * 60 * 60
+
+// PermissionSet is an in-memory store of the roles that accounts hold per permission scope.
+//
+// Queries follow the runtime's permission checks: roles are matched exactly, there is no hierarchy
+// between pool roles, and roles that carry a moment (TrancheInvestor and Holder) are only valid
+// while that moment is not in the past.
+type PermissionSet struct {
+	permissions map[permissionKey]map[Role]types.U64
+}
+
+type permissionKey struct {
+	Account types.AccountID
+	Scope   PermissionScope
+}
+
+func NewPermissionSet() *PermissionSet {
+	return &PermissionSet{
+		permissions: make(map[permissionKey]map[Role]types.U64),
+	}
+}
+
+// Add grants the role to the account at the provided moment.
+//
+// Similarly to the runtime, a TrancheInvestor or Holder role is valid until its moment but at least until
+// now plus MinDelay. Adding the role again extends its validity, ErrInvalidRole is returned if it would shorten it.
+func (p *PermissionSet) Add(account types.AccountID, scope PermissionScope, role Role, now types.U64) error {
+	if err := validateScopeRole(scope, role); err != nil {
+		return err
+	}
+
+	identity, moment := splitRole(role)
+
+	key := permissionKey{account, scope}
+
+	roles, ok := p.permissions[key]
+
+	if isTimeBound(identity) {
+		if minValidity := addDelay(now, MinDelay); moment < minValidity {
+			moment = minValidity
+		}
+
+		if expiry, ok := roles[identity]; ok && expiry > moment {
+			return fmt.Errorf("%w: role is valid until a later moment", ErrInvalidRole)
+		}
+	}
+
+	if !ok {
+		roles = make(map[Role]types.U64)
+
+		p.permissions[key] = roles
+	}
+
+	roles[identity] = moment
+
+	return nil
+}
+
+// Remove revokes the role of the account at the provided moment.
+//
+// Similarly to the runtime, roles that carry a moment are not removed right away. The moment of the removed role
+// is a delay, the role remains valid until now plus that delay but at least until now plus MinDelay.
+// ErrRoleExpired is returned if the role is no longer valid at the provided moment.
+func (p *PermissionSet) Remove(account types.AccountID, scope PermissionScope, role Role, now types.U64) error {
+	key := permissionKey{account, scope}
+
+	roles, ok := p.permissions[key]
+
+	if !ok {
+		return ErrRoleNotFound
+	}
+
+	identity, delay := splitRole(role)
+
+	expiry, ok := roles[identity]
+
+	if !ok {
+		return ErrRoleNotFound
+	}
+
+	if isTimeBound(identity) {
+		if expiry <= now {
+			return ErrRoleExpired
+		}
+
+		if delay < MinDelay {
+			delay = MinDelay
+		}
+
+		roles[identity] = addDelay(now, delay)
+
+		return nil
+	}
+
+	delete(roles, identity)
+
+	if len(roles) == 0 {
+		delete(p.permissions, key)
+	}
+
+	return nil
+}
+
+// role returns the stored role of the account that matches the identity, with its expiry.
+func (p *PermissionSet) role(account types.AccountID, scope PermissionScope, identity Role) (Role, bool) {
+	moment, ok := p.permissions[permissionKey{account, scope}][identity]
+
+	if !ok {
+		return Role{}, false
+	}
+
+	return joinRole(identity, moment), true
+}
+
+// addDelay returns the moment after the delay, saturating like the runtime.
+func addDelay(now, delay types.U64) types.U64 {
+	if now > math.MaxUint64-delay {
+		return math.MaxUint64
+	}
+
+	return now + delay
+}
+
+// Purge removes all the roles of the account in the scope.
+func (p *PermissionSet) Purge(account types.AccountID, scope PermissionScope) {
+	delete(p.permissions, permissionKey{account, scope})
+}
+
+// Has returns true if the account holds the role in the scope at the provided moment.
+// The moment of a TrancheInvestor or Holder role in the query is ignored, only the stored expiry is checked.
+func (p *PermissionSet) Has(account types.AccountID, scope PermissionScope, role Role, now types.U64) bool {
+	roles, ok := p.permissions[permissionKey{account, scope}]
+
+	if !ok {
+		return false
+	}
+
+	identity, _ := splitRole(role)
+
+	moment, ok := roles[identity]
+
+	if !ok {
+		return false
+	}
+
+	if isTimeBound(identity) {
+		return moment >= now
+	}
+
+	return true
+}
+
+// HasPoolRole returns true if the account holds the pool role in the pool at the provided moment.
+func (p *PermissionSet) HasPoolRole(account types.AccountID, poolID types.U64, poolRole PoolRole, now types.U64) bool {
+	return p.Has(account, NewPoolScope(poolID), NewPoolRole(poolRole), now)
+}
+
+// IsTrancheInvestor returns true if the account is allowed to invest in the tranche at the provided moment.
+func (p *PermissionSet) IsTrancheInvestor(
+	account types.AccountID,
+	poolID types.U64,
+	trancheID [16]types.U8,
+	now types.U64,
+) bool {
+	return p.Has(account, NewPoolScope(poolID), NewTrancheInvestorRole(trancheID, 0), now)
+}
+
+// Roles returns the roles stored for the account in the scope, including expired ones.
+func (p *PermissionSet) Roles(account types.AccountID, scope PermissionScope) []Role {
+	roles := p.permissions[permissionKey{account, scope}]
+
+	res := make([]Role, 0, len(roles))
+
+	for identity, moment := range roles {
+		res = append(res, joinRole(identity, moment))
+	}
+
+	sortRoles(res)
+
+	return res
+}
+
+// Entry is a single role held by an account in a scope.
+type Entry struct {
+	Account types.AccountID
+	Scope   PermissionScope
+	Role    Role
+}
+
+// Entries returns all the stored roles.
+func (p *PermissionSet) Entries() []Entry {
+	var res []Entry
+
+	for key, roles := range p.permissions {
+		for identity, moment := range roles {
+			res = append(res, Entry{
+				Account: key.Account,
+				Scope:   key.Scope,
+				Role:    joinRole(identity, moment),
+			})
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return entryLess(res[i], res[j])
+	})
+
+	return res
+}
+
+func validateScopeRole(scope PermissionScope, role Role) error {
+	switch {
+	case scope.IsPool:
+		if !role.IsPoolRole {
+			return ErrInvalidRole
+		}
+	case scope.IsCurrency:
+		if !role.IsPermissionedCurrencyRole {
+			return ErrInvalidRole
+		}
+	default:
+		return ErrInvalidScope
+	}
+
+	return nil
+}
+
+// splitRole splits the role into its identity, without any moment, and its moment.
+func splitRole(role Role) (Role, types.U64) {
+	switch {
+	case role.IsPoolRole && role.AsPoolRole.IsTrancheInvestor:
+		moment := role.AsPoolRole.AsTrancheInvestor.Moment
+		role.AsPoolRole.AsTrancheInvestor.Moment = 0
+
+		return role, moment
+	case role.IsPermissionedCurrencyRole && role.AsPermissionedCurrencyRole.IsHolder:
+		moment := role.AsPermissionedCurrencyRole.AsHolder
+		role.AsPermissionedCurrencyRole.AsHolder = 0
+
+		return role, moment
+	default:
+		return role, 0
+	}
+}
+
+func joinRole(identity Role, moment types.U64) Role {
+	switch {
+	case identity.IsPoolRole && identity.AsPoolRole.IsTrancheInvestor:
+		identity.AsPoolRole.AsTrancheInvestor.Moment = moment
+	case identity.IsPermissionedCurrencyRole && identity.AsPermissionedCurrencyRole.IsHolder:
+		identity.AsPermissionedCurrencyRole.AsHolder = moment
+	}
+
+	return identity
+}
+
+func isTimeBound(role Role) bool {
+	return (role.IsPoolRole && role.AsPoolRole.IsTrancheInvestor) ||
+		(role.IsPermissionedCurrencyRole && role.AsPermissionedCurrencyRole.IsHolder)
+}
+
+func sortRoles(roles []Role) {
+	sort.Slice(roles, func(i, j int) bool {
+		return roleLess(roles[i], roles[j])
+	})
+}
+
+func entryLess(a, b Entry) bool {
+	if a.Account != b.Account {
+		return string(a.Account[:]) < string(b.Account[:])
+	}
+
+	if a.Scope != b.Scope {
+		return scopeLess(a.Scope, b.Scope)
+	}
+
+	return roleLess(a.Role, b.Role)
+}
+
+func scopeLess(a, b PermissionScope) bool {
+	if a.IsPool != b.IsPool {
+		return a.IsPool
+	}
+
+	if a.IsPool {
+		return a.AsPool < b.AsPool
+	}
+
+	return a.AsCurrency.String() < b.AsCurrency.String()
+}
+
+func roleLess(a, b Role) bool {
+	encodedA, _ := codec.Encode(a)
+	encodedB, _ := codec.Encode(b)
+
+	return string(encodedA) < string(encodedB)
+}
@@ -0,0 +1,112 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testAccount1 = types.AccountID{1}
+	testAccount2 = types.AccountID{2}
+
+	testTrancheID = [16]types.U8{1, 2, 3}
+)
+
+func TestPermissionSet_PoolRoles(t *testing.T) {
+	set := NewPermissionSet()
+
+	assert.NoError(t, set.Add(testAccount1, NewPoolScope(7), NewPoolRole(PoolRole{IsLoanAdmin: true}), 0))
+
+	assert.True(t, set.HasPoolRole(testAccount1, 7, PoolRole{IsLoanAdmin: true}, 0))
+	assert.False(t, set.HasPoolRole(testAccount1, 8, PoolRole{IsLoanAdmin: true}, 0))
+	assert.False(t, set.HasPoolRole(testAccount2, 7, PoolRole{IsLoanAdmin: true}, 0))
+
+	// Pool roles don't imply each other.
+	assert.NoError(t, set.Add(testAccount2, NewPoolScope(7), NewPoolRole(PoolRole{IsPoolAdmin: true}), 0))
+	assert.False(t, set.HasPoolRole(testAccount2, 7, PoolRole{IsLoanAdmin: true}, 0))
+
+	assert.NoError(t, set.Remove(testAccount1, NewPoolScope(7), NewPoolRole(PoolRole{IsLoanAdmin: true}), 0))
+	assert.False(t, set.HasPoolRole(testAccount1, 7, PoolRole{IsLoanAdmin: true}, 0))
+	assert.ErrorIs(
+		t,
+		set.Remove(testAccount1, NewPoolScope(7), NewPoolRole(PoolRole{IsLoanAdmin: true}), 0),
+		ErrRoleNotFound,
+	)
+}
+
+func TestPermissionSet_TrancheInvestor(t *testing.T) {
+	set := NewPermissionSet()
+	scope := NewPoolScope(7)
+
+	now := types.U64(1_000_000)
+	expiry := now + 2*MinDelay
+
+	assert.NoError(t, set.Add(testAccount1, scope, NewTrancheInvestorRole(testTrancheID, expiry), now))
+
+	assert.True(t, set.IsTrancheInvestor(testAccount1, 7, testTrancheID, now))
+	assert.True(t, set.IsTrancheInvestor(testAccount1, 7, testTrancheID, expiry))
+	assert.False(t, set.IsTrancheInvestor(testAccount1, 7, testTrancheID, expiry+1))
+	assert.False(t, set.IsTrancheInvestor(testAccount1, 7, [16]types.U8{4}, now))
+
+	// Adding the role again extends the permission but can't shorten it.
+	assert.ErrorIs(t, set.Add(testAccount1, scope, NewTrancheInvestorRole(testTrancheID, expiry-1), now), ErrInvalidRole)
+	assert.NoError(t, set.Add(testAccount1, scope, NewTrancheInvestorRole(testTrancheID, expiry+MinDelay), now))
+	assert.True(t, set.IsTrancheInvestor(testAccount1, 7, testTrancheID, expiry+MinDelay))
+
+	// Removing the role keeps it valid for its delay, but at least for MinDelay.
+	assert.NoError(t, set.Remove(testAccount1, scope, NewTrancheInvestorRole(testTrancheID, 10), now))
+	assert.True(t, set.IsTrancheInvestor(testAccount1, 7, testTrancheID, now+MinDelay))
+	assert.False(t, set.IsTrancheInvestor(testAccount1, 7, testTrancheID, now+MinDelay+1))
+	assert.Equal(t, []Role{NewTrancheInvestorRole(testTrancheID, now+MinDelay)}, set.Roles(testAccount1, scope))
+
+	assert.NoError(t, set.Remove(testAccount1, scope, NewTrancheInvestorRole(testTrancheID, 2*MinDelay), now+1))
+	assert.Equal(t, []Role{NewTrancheInvestorRole(testTrancheID, now+1+2*MinDelay)}, set.Roles(testAccount1, scope))
+
+	// Expired roles can't be removed.
+	assert.ErrorIs(
+		t,
+		set.Remove(testAccount1, scope, NewTrancheInvestorRole(testTrancheID, 0), now+1+2*MinDelay),
+		ErrRoleExpired,
+	)
+
+	// The role is valid for at least MinDelay when it's added.
+	assert.NoError(t, set.Add(testAccount2, scope, NewTrancheInvestorRole(testTrancheID, 0), now))
+	assert.Equal(t, []Role{NewTrancheInvestorRole(testTrancheID, now+MinDelay)}, set.Roles(testAccount2, scope))
+}
+
+func TestPermissionSet_CurrencyRoles(t *testing.T) {
+	set := NewPermissionSet()
+	scope := NewCurrencyScope(currency.NewAUSDCurrencyID())
+
+	holder := NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsHolder: true})
+	manager := NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsManager: true})
+	poolAdmin := NewPoolRole(PoolRole{IsPoolAdmin: true})
+
+	assert.NoError(t, set.Add(testAccount1, scope, NewPermissionedCurrencyRole(PermissionedCurrencyRole{
+		IsHolder: true,
+		AsHolder: MinDelay + 10,
+	}), 0))
+	assert.True(t, set.Has(testAccount1, scope, holder, MinDelay+10))
+	assert.False(t, set.Has(testAccount1, scope, holder, MinDelay+11))
+	assert.False(t, set.Has(testAccount1, scope, manager, 0))
+
+	assert.ErrorIs(t, set.Add(testAccount1, scope, poolAdmin, 0), ErrInvalidRole)
+	assert.ErrorIs(t, set.Add(testAccount1, PermissionScope{}, poolAdmin, 0), ErrInvalidScope)
+}
+
+func TestPermissionSet_Purge(t *testing.T) {
+	set := NewPermissionSet()
+
+	assert.NoError(t, set.Add(testAccount1, NewPoolScope(7), NewPoolRole(PoolRole{IsBorrower: true}), 0))
+	assert.NoError(t, set.Add(testAccount1, NewPoolScope(7), NewPoolRole(PoolRole{IsPricingAdmin: true}), 0))
+	assert.NoError(t, set.Add(testAccount1, NewPoolScope(8), NewPoolRole(PoolRole{IsBorrower: true}), 0))
+	assert.Len(t, set.Entries(), 3)
+
+	set.Purge(testAccount1, NewPoolScope(7))
+
+	assert.Empty(t, set.Roles(testAccount1, NewPoolScope(7)))
+	assert.True(t, set.HasPoolRole(testAccount1, 8, PoolRole{IsBorrower: true}, 0))
+}
@@ -3,6 +3,7 @@ package permissions
 import (
 	"errors"
 
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
@@ -35,7 +36,7 @@ type PermissionScope struct {
 	AsPool types.U64
 
 	IsCurrency bool
-	AsCurrency types.CurrencyID
+	AsCurrency currency.CurrencyID
 }
 
 func (p *PermissionScope) Decode(decoder scale.Decoder) error {
@@ -257,7 +258,7 @@ func (p *PermissionedCurrencyRole) Decode(decoder scale.Decoder) error {
 	}
 }
 
-func (p PermissionedCurrencyRole) PermissionedCurrencyRole(encoder scale.Encoder) error {
+func (p PermissionedCurrencyRole) Encode(encoder scale.Encoder) error {
 	switch {
 	case p.IsHolder:
 		if err := encoder.PushByte(0); err != nil {
@@ -273,3 +274,41 @@ func (p PermissionedCurrencyRole) PermissionedCurrencyRole(encoder scale.Encoder
 		return errors.New("unsupported permissioned currency role")
 	}
 }
+
+func NewPoolScope(poolID types.U64) PermissionScope {
+	return PermissionScope{
+		IsPool: true,
+		AsPool: poolID,
+	}
+}
+
+func NewCurrencyScope(currencyID currency.CurrencyID) PermissionScope {
+	return PermissionScope{
+		IsCurrency: true,
+		AsCurrency: currencyID,
+	}
+}
+
+func NewPoolRole(poolRole PoolRole) Role {
+	return Role{
+		IsPoolRole: true,
+		AsPoolRole: poolRole,
+	}
+}
+
+func NewTrancheInvestorRole(trancheID [16]types.U8, moment types.U64) Role {
+	return NewPoolRole(PoolRole{
+		IsTrancheInvestor: true,
+		AsTrancheInvestor: TrancheInvestor{
+			TrancheID: trancheID,
+			Moment:    moment,
+		},
+	})
+}
+
+func NewPermissionedCurrencyRole(currencyRole PermissionedCurrencyRole) Role {
+	return Role{
+		IsPermissionedCurrencyRole: true,
+		AsPermissionedCurrencyRole: currencyRole,
+	}
+}
@@ -0,0 +1,269 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/chain-custom-types/pkg/internal/testmeta"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	fuzz "github.com/google/gofuzz"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+)
+
+var (
+	testMeta = testmeta.NewMetadata(testmeta.Pallet{
+		Name:  "Permissions",
+		Index: 98,
+		Calls: []string{"add", "remove", "purge", "admin_purge"},
+		Storage: []testmeta.Storage{
+			{Name: "Permission", Keys: 2},
+		},
+	})
+
+	testTrancheInvestor = TrancheInvestor{
+		TrancheID: [16]types.U8{0xab, 0xcd},
+		Moment:    100,
+	}
+
+	currencyIDFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(cID *currency.CurrencyID, c fuzz.Continue) {
+			switch c.Intn(3) {
+			case 0:
+				*cID = currency.NewNativeCurrencyID()
+			case 1:
+				*cID = currency.NewAUSDCurrencyID()
+			case 2:
+				*cID = currency.NewForeignAssetCurrencyID(types.U32(c.Uint32()))
+			}
+		}),
+	}
+)
+
+func TestTrancheInvestor_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[TrancheInvestor](t, 1000)
+	AssertDecodeNilData[TrancheInvestor](t)
+	AssertEncodeEmptyObj[TrancheInvestor](t, 24)
+}
+
+func TestTrancheInvestor_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{
+			Input:    testTrancheInvestor,
+			Expected: codec.MustHexDecodeString("0xabcd0000000000000000000000000000" + "6400000000000000"),
+		},
+	})
+}
+
+func TestTrancheInvestor_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{
+			Input:    codec.MustHexDecodeString("0xabcd0000000000000000000000000000" + "6400000000000000"),
+			Expected: testTrancheInvestor,
+		},
+	})
+}
+
+var (
+	poolRoleFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(p *PoolRole, c fuzz.Continue) {
+			switch c.Intn(8) {
+			case 0:
+				p.IsPoolAdmin = true
+			case 1:
+				p.IsBorrower = true
+			case 2:
+				p.IsPricingAdmin = true
+			case 3:
+				p.IsLiquidityAdmin = true
+			case 4:
+				p.IsMemberListAdmin = true
+			case 5:
+				p.IsLoanAdmin = true
+			case 6:
+				p.IsTrancheInvestor = true
+				c.Fuzz(&p.AsTrancheInvestor)
+			case 7:
+				p.IsPODReadAccess = true
+			}
+		}),
+	}
+)
+
+func TestPoolRole_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[PoolRole](t, 1000, poolRoleFuzzOpts...)
+	AssertDecodeNilData[PoolRole](t)
+}
+
+func TestPoolRole_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{Input: PoolRole{IsPoolAdmin: true}, Expected: codec.MustHexDecodeString("0x00")},
+		{Input: PoolRole{IsBorrower: true}, Expected: codec.MustHexDecodeString("0x01")},
+		{Input: PoolRole{IsPricingAdmin: true}, Expected: codec.MustHexDecodeString("0x02")},
+		{Input: PoolRole{IsLiquidityAdmin: true}, Expected: codec.MustHexDecodeString("0x03")},
+		{Input: PoolRole{IsMemberListAdmin: true}, Expected: codec.MustHexDecodeString("0x04")},
+		{Input: PoolRole{IsLoanAdmin: true}, Expected: codec.MustHexDecodeString("0x05")},
+		{
+			Input:    PoolRole{IsTrancheInvestor: true, AsTrancheInvestor: testTrancheInvestor},
+			Expected: codec.MustHexDecodeString("0x06abcd0000000000000000000000000000" + "6400000000000000"),
+		},
+		{Input: PoolRole{IsPODReadAccess: true}, Expected: codec.MustHexDecodeString("0x07")},
+	})
+}
+
+func TestPoolRole_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{Input: codec.MustHexDecodeString("0x00"), Expected: PoolRole{IsPoolAdmin: true}},
+		{Input: codec.MustHexDecodeString("0x01"), Expected: PoolRole{IsBorrower: true}},
+		{Input: codec.MustHexDecodeString("0x02"), Expected: PoolRole{IsPricingAdmin: true}},
+		{Input: codec.MustHexDecodeString("0x03"), Expected: PoolRole{IsLiquidityAdmin: true}},
+		{Input: codec.MustHexDecodeString("0x04"), Expected: PoolRole{IsMemberListAdmin: true}},
+		{Input: codec.MustHexDecodeString("0x05"), Expected: PoolRole{IsLoanAdmin: true}},
+		{
+			Input:    codec.MustHexDecodeString("0x06abcd0000000000000000000000000000" + "6400000000000000"),
+			Expected: PoolRole{IsTrancheInvestor: true, AsTrancheInvestor: testTrancheInvestor},
+		},
+		{Input: codec.MustHexDecodeString("0x07"), Expected: PoolRole{IsPODReadAccess: true}},
+	})
+}
+
+var (
+	permissionedCurrencyRoleFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(p *PermissionedCurrencyRole, c fuzz.Continue) {
+			switch c.Intn(3) {
+			case 0:
+				p.IsHolder = true
+				c.Fuzz(&p.AsHolder)
+			case 1:
+				p.IsManager = true
+			case 2:
+				p.IsIssuer = true
+			}
+		}),
+	}
+)
+
+func TestPermissionedCurrencyRole_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[PermissionedCurrencyRole](t, 1000, permissionedCurrencyRoleFuzzOpts...)
+	AssertDecodeNilData[PermissionedCurrencyRole](t)
+}
+
+func TestPermissionedCurrencyRole_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{
+			Input:    PermissionedCurrencyRole{IsHolder: true, AsHolder: 10},
+			Expected: codec.MustHexDecodeString("0x000a00000000000000"),
+		},
+		{Input: PermissionedCurrencyRole{IsManager: true}, Expected: codec.MustHexDecodeString("0x01")},
+		{Input: PermissionedCurrencyRole{IsIssuer: true}, Expected: codec.MustHexDecodeString("0x02")},
+	})
+}
+
+func TestPermissionedCurrencyRole_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{
+			Input:    codec.MustHexDecodeString("0x000a00000000000000"),
+			Expected: PermissionedCurrencyRole{IsHolder: true, AsHolder: 10},
+		},
+		{Input: codec.MustHexDecodeString("0x01"), Expected: PermissionedCurrencyRole{IsManager: true}},
+		{Input: codec.MustHexDecodeString("0x02"), Expected: PermissionedCurrencyRole{IsIssuer: true}},
+	})
+}
+
+var (
+	roleFuzzOpts = CombineFuzzOpts(
+		poolRoleFuzzOpts,
+		permissionedCurrencyRoleFuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(r *Role, c fuzz.Continue) {
+				if c.RandBool() {
+					r.IsPoolRole = true
+					c.Fuzz(&r.AsPoolRole)
+					return
+				}
+
+				r.IsPermissionedCurrencyRole = true
+				c.Fuzz(&r.AsPermissionedCurrencyRole)
+			}),
+		},
+	)
+)
+
+func TestRole_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[Role](t, 1000, roleFuzzOpts...)
+	AssertDecodeNilData[Role](t)
+}
+
+func TestRole_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{Input: NewPoolRole(PoolRole{IsPoolAdmin: true}), Expected: codec.MustHexDecodeString("0x0000")},
+		{
+			Input:    NewTrancheInvestorRole(testTrancheInvestor.TrancheID, testTrancheInvestor.Moment),
+			Expected: codec.MustHexDecodeString("0x0006abcd0000000000000000000000000000" + "6400000000000000"),
+		},
+		{
+			Input:    NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsHolder: true, AsHolder: 10}),
+			Expected: codec.MustHexDecodeString("0x01000a00000000000000"),
+		},
+		{
+			Input:    NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsIssuer: true}),
+			Expected: codec.MustHexDecodeString("0x0102"),
+		},
+	})
+}
+
+func TestRole_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{Input: codec.MustHexDecodeString("0x0000"), Expected: NewPoolRole(PoolRole{IsPoolAdmin: true})},
+		{
+			Input:    codec.MustHexDecodeString("0x0006abcd0000000000000000000000000000" + "6400000000000000"),
+			Expected: NewTrancheInvestorRole(testTrancheInvestor.TrancheID, testTrancheInvestor.Moment),
+		},
+		{
+			Input:    codec.MustHexDecodeString("0x01000a00000000000000"),
+			Expected: NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsHolder: true, AsHolder: 10}),
+		},
+	})
+}
+
+var (
+	permissionScopeFuzzOpts = CombineFuzzOpts(
+		currencyIDFuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(p *PermissionScope, c fuzz.Continue) {
+				if c.RandBool() {
+					p.IsPool = true
+					c.Fuzz(&p.AsPool)
+					return
+				}
+
+				p.IsCurrency = true
+				c.Fuzz(&p.AsCurrency)
+			}),
+		},
+	)
+)
+
+func TestPermissionScope_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[PermissionScope](t, 1000, permissionScopeFuzzOpts...)
+	AssertDecodeNilData[PermissionScope](t)
+}
+
+func TestPermissionScope_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{Input: NewPoolScope(42), Expected: codec.MustHexDecodeString("0x002a00000000000000")},
+		{Input: NewCurrencyScope(currency.NewAUSDCurrencyID()), Expected: codec.MustHexDecodeString("0x0103")},
+		{
+			Input:    NewCurrencyScope(currency.NewForeignAssetCurrencyID(1)),
+			Expected: codec.MustHexDecodeString("0x010401000000"),
+		},
+	})
+}
+
+func TestPermissionScope_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{Input: codec.MustHexDecodeString("0x002a00000000000000"), Expected: NewPoolScope(42)},
+		{Input: codec.MustHexDecodeString("0x0103"), Expected: NewCurrencyScope(currency.NewAUSDCurrencyID())},
+	})
+}
@@ -0,0 +1,163 @@
+package permissions
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// PermissionRoles is the value of the `Permission` storage of the `permissions` pallet,
+// which holds all the roles of an account in a scope.
+type PermissionRoles struct {
+	PoolAdmin               PoolAdminRoles
+	CurrencyAdmin           CurrencyAdminRoles
+	PermissionedAssetHolder PermissionedCurrencyHolders
+	TrancheInvestor         TrancheInvestors
+}
+
+// Roles returns the roles stored in the permission roles.
+func (p PermissionRoles) Roles() []Role {
+	var roles []Role
+
+	for _, flag := range poolAdminRoleFlags {
+		if p.PoolAdmin.Contains(flag.flag) {
+			roles = append(roles, NewPoolRole(flag.role))
+		}
+	}
+
+	for _, info := range p.TrancheInvestor.Info {
+		roles = append(roles, NewTrancheInvestorRole(info.TrancheID, info.PermissionedTill))
+	}
+
+	if ok, info := p.PermissionedAssetHolder.Info.Unwrap(); ok {
+		roles = append(roles, NewPermissionedCurrencyRole(PermissionedCurrencyRole{
+			IsHolder: true,
+			AsHolder: info.PermissionedTill,
+		}))
+	}
+
+	if p.CurrencyAdmin.Contains(PermissionedAssetManager) {
+		roles = append(roles, NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsManager: true}))
+	}
+
+	if p.CurrencyAdmin.Contains(PermissionedAssetIssuer) {
+		roles = append(roles, NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsIssuer: true}))
+	}
+
+	return roles
+}
+
+// PoolAdminRoles holds the pool roles, besides TrancheInvestor, as bit flags.
+type PoolAdminRoles types.U32
+
+const (
+	PoolAdminRolePoolAdmin PoolAdminRoles = 1 << iota
+	PoolAdminRoleBorrower
+	PoolAdminRolePricingAdmin
+	PoolAdminRoleLiquidityAdmin
+	PoolAdminRoleMemberListAdmin
+	PoolAdminRoleLoanAdmin
+	PoolAdminRolePODReadAccess
+)
+
+var (
+	poolAdminRoleFlags = []struct {
+		flag PoolAdminRoles
+		role PoolRole
+	}{
+		{PoolAdminRolePoolAdmin, PoolRole{IsPoolAdmin: true}},
+		{PoolAdminRoleBorrower, PoolRole{IsBorrower: true}},
+		{PoolAdminRolePricingAdmin, PoolRole{IsPricingAdmin: true}},
+		{PoolAdminRoleLiquidityAdmin, PoolRole{IsLiquidityAdmin: true}},
+		{PoolAdminRoleMemberListAdmin, PoolRole{IsMemberListAdmin: true}},
+		{PoolAdminRoleLoanAdmin, PoolRole{IsLoanAdmin: true}},
+		{PoolAdminRolePODReadAccess, PoolRole{IsPODReadAccess: true}},
+	}
+)
+
+func (p PoolAdminRoles) Contains(flag PoolAdminRoles) bool {
+	return p&flag == flag
+}
+
+func (p *PoolAdminRoles) Decode(decoder scale.Decoder) error {
+	var v types.U32
+
+	if err := decoder.Decode(&v); err != nil {
+		return err
+	}
+
+	*p = PoolAdminRoles(v)
+
+	return nil
+}
+
+func (p PoolAdminRoles) Encode(encoder scale.Encoder) error {
+	return encoder.Encode(types.U32(p))
+}
+
+// CurrencyAdminRoles holds the Manager and Issuer permissioned currency roles as bit flags.
+type CurrencyAdminRoles types.U32
+
+const (
+	PermissionedAssetManager CurrencyAdminRoles = 1 << iota
+	PermissionedAssetIssuer
+)
+
+func (c CurrencyAdminRoles) Contains(flag CurrencyAdminRoles) bool {
+	return c&flag == flag
+}
+
+func (c *CurrencyAdminRoles) Decode(decoder scale.Decoder) error {
+	var v types.U32
+
+	if err := decoder.Decode(&v); err != nil {
+		return err
+	}
+
+	*c = CurrencyAdminRoles(v)
+
+	return nil
+}
+
+func (c CurrencyAdminRoles) Encode(encoder scale.Encoder) error {
+	return encoder.Encode(types.U32(c))
+}
+
+type PermissionedCurrencyHolders struct {
+	Info types.Option[PermissionedCurrencyHolderInfo]
+}
+
+type PermissionedCurrencyHolderInfo struct {
+	PermissionedTill types.U64
+}
+
+type TrancheInvestors struct {
+	Info []TrancheInvestorInfo
+}
+
+type TrancheInvestorInfo struct {
+	TrancheID        [16]types.U8
+	PermissionedTill types.U64
+}
+
+const (
+	permissionsPrefix    = "Permissions"
+	permissionMethodName = "Permission"
+)
+
+// PermissionStorageKey returns the storage key of the permission roles of an account in a scope.
+func PermissionStorageKey(meta *types.Metadata, account types.AccountID, scope PermissionScope) (types.StorageKey, error) {
+	encodedAccount, err := codec.Encode(account)
+
+	if err != nil {
+		return nil, err
+	}
+
+	encodedScope, err := codec.Encode(scope)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreateStorageKey(meta, permissionsPrefix, permissionMethodName, encodedAccount, encodedScope)
+}
@@ -0,0 +1,81 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/internal/testmeta"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+)
+
+var (
+	testPermissionRoles = PermissionRoles{
+		PoolAdmin:     PoolAdminRolePoolAdmin | PoolAdminRoleLoanAdmin,
+		CurrencyAdmin: PermissionedAssetIssuer,
+		PermissionedAssetHolder: PermissionedCurrencyHolders{
+			Info: types.NewOption(PermissionedCurrencyHolderInfo{PermissionedTill: 10}),
+		},
+		TrancheInvestor: TrancheInvestors{
+			Info: []TrancheInvestorInfo{
+				{TrancheID: [16]types.U8{0xab, 0xcd}, PermissionedTill: 100},
+			},
+		},
+	}
+)
+
+func TestPermissionRoles_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[PermissionRoles](t, 1000)
+	AssertDecodeNilData[PermissionRoles](t)
+	AssertEncodeEmptyObj[PermissionRoles](t, 10)
+}
+
+func TestPermissionRoles_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{
+			Input: testPermissionRoles,
+			Expected: codec.MustHexDecodeString(
+				"0x21000000" + "02000000" + "010a00000000000000" + "04abcd0000000000000000000000000000" + "6400000000000000",
+			),
+		},
+	})
+}
+
+func TestPermissionRoles_Roles(t *testing.T) {
+	assert.Equal(
+		t,
+		[]Role{
+			NewPoolRole(PoolRole{IsPoolAdmin: true}),
+			NewPoolRole(PoolRole{IsLoanAdmin: true}),
+			NewTrancheInvestorRole([16]types.U8{0xab, 0xcd}, 100),
+			NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsHolder: true, AsHolder: 10}),
+			NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsIssuer: true}),
+		},
+		testPermissionRoles.Roles(),
+	)
+}
+
+func TestPermissionStorageKey(t *testing.T) {
+	account := types.AccountID{1}
+	scope := NewPoolScope(1)
+
+	key, err := PermissionStorageKey(testMeta, account, scope)
+	assert.NoError(t, err)
+
+	// Twox128("Permissions") ++ Twox128("Permission"), followed by the Blake2_128Concat account and scope.
+	assert.Equal(
+		t,
+		"0xc2b6ac49ee131be4de5527a2ccab4a67d8a233a5123f5da90f0e75b26ae09491",
+		codec.HexEncodeToString(key[:32]),
+	)
+
+	encodedAccount := account[:]
+	encodedScope := codec.MustHexDecodeString("0x000100000000000000")
+
+	assert.Equal(t, testmeta.Blake2_128(encodedAccount), []byte(key[32:48]))
+	assert.Equal(t, encodedAccount, []byte(key[48:80]))
+	assert.Equal(t, testmeta.Blake2_128(encodedScope), []byte(key[80:96]))
+	assert.Equal(t, encodedScope, []byte(key[96:]))
+}
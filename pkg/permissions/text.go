@@ -0,0 +1,273 @@
+package permissions
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// The text form of a permission is `<scope>/<role>`, for example:
+//
+//	pool:42/PoolAdmin
+//	pool:42/TrancheInvestor(0xabcd0000000000000000000000000000,2026-12-31)
+//	currency:AUSD/Holder(2026-12-31T12:00:00Z)
+//	currency:ForeignAsset(1)/Manager
+//
+// Moments are formatted as a date if they fall on midnight UTC, as an RFC 3339 timestamp otherwise,
+// and are parsed from either form or from a plain number of seconds.
+
+var (
+	ErrInvalidText = errors.New("invalid permission text")
+)
+
+const (
+	poolScopePrefix     = "pool:"
+	currencyScopePrefix = "currency:"
+
+	dateLayout = "2006-01-02"
+)
+
+func (p PermissionScope) String() string {
+	switch {
+	case p.IsPool:
+		return fmt.Sprintf("%s%d", poolScopePrefix, p.AsPool)
+	case p.IsCurrency:
+		return currencyScopePrefix + p.AsCurrency.String()
+	default:
+		return "unknown"
+	}
+}
+
+func (r Role) String() string {
+	switch {
+	case r.IsPoolRole:
+		return r.AsPoolRole.String()
+	case r.IsPermissionedCurrencyRole:
+		return r.AsPermissionedCurrencyRole.String()
+	default:
+		return "Unknown"
+	}
+}
+
+func (p PoolRole) String() string {
+	switch {
+	case p.IsPoolAdmin:
+		return "PoolAdmin"
+	case p.IsBorrower:
+		return "Borrower"
+	case p.IsPricingAdmin:
+		return "PricingAdmin"
+	case p.IsLiquidityAdmin:
+		return "LiquidityAdmin"
+	case p.IsMemberListAdmin:
+		return "MemberListAdmin"
+	case p.IsLoanAdmin:
+		return "LoanAdmin"
+	case p.IsTrancheInvestor:
+		return fmt.Sprintf(
+			"TrancheInvestor(%s,%s)",
+			currency.FormatTrancheID(p.AsTrancheInvestor.TrancheID),
+			FormatMoment(p.AsTrancheInvestor.Moment),
+		)
+	case p.IsPODReadAccess:
+		return "PODReadAccess"
+	default:
+		return "Unknown"
+	}
+}
+
+func (p PermissionedCurrencyRole) String() string {
+	switch {
+	case p.IsHolder:
+		return fmt.Sprintf("Holder(%s)", FormatMoment(p.AsHolder))
+	case p.IsManager:
+		return "Manager"
+	case p.IsIssuer:
+		return "Issuer"
+	default:
+		return "Unknown"
+	}
+}
+
+// FormatPermission returns the text form of a role in a scope.
+func FormatPermission(scope PermissionScope, role Role) string {
+	return scope.String() + "/" + role.String()
+}
+
+// ParsePermission parses the text form of a role in a scope, and checks that the role is valid for the scope.
+func ParsePermission(s string) (PermissionScope, Role, error) {
+	scopeText, roleText, ok := strings.Cut(strings.TrimSpace(s), "/")
+
+	if !ok {
+		return PermissionScope{}, Role{}, fmt.Errorf("%w: expected <scope>/<role>", ErrInvalidText)
+	}
+
+	scope, err := ParseScope(scopeText)
+
+	if err != nil {
+		return PermissionScope{}, Role{}, err
+	}
+
+	role, err := ParseRole(roleText)
+
+	if err != nil {
+		return PermissionScope{}, Role{}, err
+	}
+
+	if err := validateScopeRole(scope, role); err != nil {
+		return PermissionScope{}, Role{}, fmt.Errorf("%w: %s is not valid for %s", err, role, scope)
+	}
+
+	return scope, role, nil
+}
+
+// ParseScope parses the text form of a permission scope.
+func ParseScope(s string) (PermissionScope, error) {
+	s = strings.TrimSpace(s)
+
+	switch {
+	case strings.HasPrefix(s, poolScopePrefix):
+		poolID, err := strconv.ParseUint(strings.TrimPrefix(s, poolScopePrefix), 10, 64)
+
+		if err != nil {
+			return PermissionScope{}, fmt.Errorf("%w: invalid pool ID: %s", ErrInvalidText, err)
+		}
+
+		return NewPoolScope(types.U64(poolID)), nil
+	case strings.HasPrefix(s, currencyScopePrefix):
+		currencyID, err := currency.ParseCurrencyID(strings.TrimPrefix(s, currencyScopePrefix))
+
+		if err != nil {
+			return PermissionScope{}, fmt.Errorf("%w: %s", ErrInvalidText, err)
+		}
+
+		return NewCurrencyScope(currencyID), nil
+	default:
+		return PermissionScope{}, fmt.Errorf("%w: unknown scope %q", ErrInvalidText, s)
+	}
+}
+
+// ParseRole parses the text form of a role.
+func ParseRole(s string) (Role, error) {
+	s = strings.TrimSpace(s)
+
+	name, args, hasArgs := s, "", false
+
+	if open := strings.Index(s, "("); open >= 0 {
+		if !strings.HasSuffix(s, ")") {
+			return Role{}, fmt.Errorf("%w: missing closing parenthesis in %q", ErrInvalidText, s)
+		}
+
+		name, args, hasArgs = s[:open], s[open+1:len(s)-1], true
+	}
+
+	switch name {
+	case "TrancheInvestor":
+		trancheText, momentText, ok := strings.Cut(args, ",")
+
+		if !hasArgs || !ok {
+			return Role{}, fmt.Errorf("%w: expected TrancheInvestor(tranche,moment)", ErrInvalidText)
+		}
+
+		trancheID, err := currency.ParseTrancheID(strings.TrimSpace(trancheText))
+
+		if err != nil {
+			return Role{}, fmt.Errorf("%w: %s", ErrInvalidText, err)
+		}
+
+		moment, err := ParseMoment(momentText)
+
+		if err != nil {
+			return Role{}, err
+		}
+
+		return NewTrancheInvestorRole(trancheID, moment), nil
+	case "Holder":
+		if !hasArgs {
+			return Role{}, fmt.Errorf("%w: expected Holder(moment)", ErrInvalidText)
+		}
+
+		moment, err := ParseMoment(args)
+
+		if err != nil {
+			return Role{}, err
+		}
+
+		return NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsHolder: true, AsHolder: moment}), nil
+	}
+
+	if hasArgs {
+		return Role{}, fmt.Errorf("%w: %s doesn't take arguments", ErrInvalidText, name)
+	}
+
+	switch name {
+	case "PoolAdmin":
+		return NewPoolRole(PoolRole{IsPoolAdmin: true}), nil
+	case "Borrower":
+		return NewPoolRole(PoolRole{IsBorrower: true}), nil
+	case "PricingAdmin":
+		return NewPoolRole(PoolRole{IsPricingAdmin: true}), nil
+	case "LiquidityAdmin":
+		return NewPoolRole(PoolRole{IsLiquidityAdmin: true}), nil
+	case "MemberListAdmin":
+		return NewPoolRole(PoolRole{IsMemberListAdmin: true}), nil
+	case "LoanAdmin":
+		return NewPoolRole(PoolRole{IsLoanAdmin: true}), nil
+	case "PODReadAccess":
+		return NewPoolRole(PoolRole{IsPODReadAccess: true}), nil
+	case "Manager":
+		return NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsManager: true}), nil
+	case "Issuer":
+		return NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsIssuer: true}), nil
+	default:
+		return Role{}, fmt.Errorf("%w: unknown role %q", ErrInvalidText, name)
+	}
+}
+
+// maxFormattedMoment is the last second of the year 9999, later moments are formatted as a number of seconds.
+const maxFormattedMoment = 253402300799
+
+// FormatMoment returns the text form of a moment in seconds.
+func FormatMoment(moment types.U64) string {
+	if moment > maxFormattedMoment {
+		return strconv.FormatUint(uint64(moment), 10)
+	}
+
+	t := time.Unix(int64(moment), 0).UTC()
+
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format(dateLayout)
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// ParseMoment parses a moment in seconds from a date, an RFC 3339 timestamp or a number of seconds.
+func ParseMoment(s string) (types.U64, error) {
+	s = strings.TrimSpace(s)
+
+	if seconds, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return types.U64(seconds), nil
+	}
+
+	t, err := time.Parse(dateLayout, s)
+
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, s)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid moment %q", ErrInvalidText, s)
+	}
+
+	if t.Unix() < 0 {
+		return 0, fmt.Errorf("%w: moment %q is out of range", ErrInvalidText, s)
+	}
+
+	return types.U64(t.Unix()), nil
+}
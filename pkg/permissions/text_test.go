@@ -0,0 +1,82 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePermission(t *testing.T) {
+	trancheID := [16]types.U8{0xab, 0xcd}
+
+	tests := []struct {
+		text  string
+		scope PermissionScope
+		role  Role
+	}{
+		{"pool:42/PoolAdmin", NewPoolScope(42), NewPoolRole(PoolRole{IsPoolAdmin: true})},
+		{"pool:42/Borrower", NewPoolScope(42), NewPoolRole(PoolRole{IsBorrower: true})},
+		{"pool:42/PricingAdmin", NewPoolScope(42), NewPoolRole(PoolRole{IsPricingAdmin: true})},
+		{"pool:42/LiquidityAdmin", NewPoolScope(42), NewPoolRole(PoolRole{IsLiquidityAdmin: true})},
+		{"pool:42/MemberListAdmin", NewPoolScope(42), NewPoolRole(PoolRole{IsMemberListAdmin: true})},
+		{"pool:42/LoanAdmin", NewPoolScope(42), NewPoolRole(PoolRole{IsLoanAdmin: true})},
+		{"pool:42/PODReadAccess", NewPoolScope(42), NewPoolRole(PoolRole{IsPODReadAccess: true})},
+		{
+			"pool:42/TrancheInvestor(0xabcd0000000000000000000000000000,2026-12-31)",
+			NewPoolScope(42),
+			NewTrancheInvestorRole(trancheID, 1798675200),
+		},
+		{
+			"currency:AUSD/Holder(2026-12-31T12:00:00Z)",
+			NewCurrencyScope(currency.NewAUSDCurrencyID()),
+			NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsHolder: true, AsHolder: 1798718400}),
+		},
+		{
+			"currency:ForeignAsset(1)/Manager",
+			NewCurrencyScope(currency.NewForeignAssetCurrencyID(1)),
+			NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsManager: true}),
+		},
+		{
+			"currency:Tranche(3,0xabcd0000000000000000000000000000)/Issuer",
+			NewCurrencyScope(currency.NewTrancheCurrencyID(3, trancheID)),
+			NewPermissionedCurrencyRole(PermissionedCurrencyRole{IsIssuer: true}),
+		},
+	}
+
+	for _, test := range tests {
+		scope, role, err := ParsePermission(test.text)
+		assert.NoError(t, err, test.text)
+		assert.Equal(t, test.scope, scope, test.text)
+		assert.Equal(t, test.role, role, test.text)
+		assert.Equal(t, test.text, FormatPermission(scope, role))
+	}
+}
+
+func TestParsePermission_Errors(t *testing.T) {
+	for _, text := range []string{
+		"pool:42",
+		"pool:x/PoolAdmin",
+		"account:1/PoolAdmin",
+		"pool:42/Admin",
+		"pool:42/PoolAdmin(1)",
+		"pool:42/TrancheInvestor(0xabcd,2026-12-31)",
+		"pool:42/TrancheInvestor(0xabcd0000000000000000000000000000,tomorrow)",
+		"currency:Foo/Manager",
+	} {
+		_, _, err := ParsePermission(text)
+		assert.ErrorIs(t, err, ErrInvalidText, text)
+	}
+
+	_, _, err := ParsePermission("pool:42/Manager")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestParseMoment(t *testing.T) {
+	moment, err := ParseMoment("1798675200")
+	assert.NoError(t, err)
+	assert.Equal(t, types.U64(1798675200), moment)
+
+	assert.Equal(t, "18446744073709551615", FormatMoment(types.U64(18446744073709551615)))
+}
@@ -0,0 +1,33 @@
+package phase
+
+import (
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Event is an event of a block together with the function that applies it.
+type Event struct {
+	Phase types.Phase
+	Apply func() error
+}
+
+// Apply applies the events in the order of their phase, stopping at the first error. Events of the same phase
+// keep their order, so callers that receive the events grouped per type must add the groups in the order
+// in which the runtime emits them.
+func Apply(events []Event) error {
+	sorted := make([]Event, len(events))
+	copy(sorted, events)
+
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return Less(sorted[a].Phase, sorted[b].Phase)
+	})
+
+	for _, event := range sorted {
+		if err := event.Apply(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
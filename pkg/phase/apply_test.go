@@ -0,0 +1,44 @@
+package phase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply(t *testing.T) {
+	var applied []string
+
+	event := func(phase types.Phase, name string) Event {
+		return Event{phase, func() error {
+			applied = append(applied, name)
+
+			return nil
+		}}
+	}
+
+	finalization := types.Phase{IsFinalization: true}
+	initialization := types.Phase{IsInitialization: true}
+	extrinsic := types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1}
+
+	err := Apply([]Event{
+		event(finalization, "finalization"),
+		event(extrinsic, "extrinsic"),
+		event(initialization, "initialization 1"),
+		event(initialization, "initialization 2"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"initialization 1", "initialization 2", "extrinsic", "finalization"}, applied)
+
+	applied = nil
+	errApply := errors.New("apply")
+
+	err = Apply([]Event{
+		event(extrinsic, "extrinsic"),
+		{initialization, func() error { return errApply }},
+	})
+	assert.ErrorIs(t, err, errApply)
+	assert.Empty(t, applied)
+}
@@ -0,0 +1,21 @@
+package phase
+
+import "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+// Index returns the position of the phase within its block. Events emitted during initialization
+// come first, followed by the events of each extrinsic and the events emitted during finalization.
+func Index(phase types.Phase) uint64 {
+	switch {
+	case phase.IsInitialization:
+		return 0
+	case phase.IsApplyExtrinsic:
+		return uint64(phase.AsApplyExtrinsic) + 1
+	default:
+		return uint64(1) << 33
+	}
+}
+
+// Less returns true if the phase a happens before the phase b.
+func Less(a, b types.Phase) bool {
+	return Index(a) < Index(b)
+}
@@ -1,6 +1,12 @@
 package pools
 
-import "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+import (
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
 
 type EventPoolRegistryRegistered struct {
 	Phase  types.Phase
@@ -32,3 +38,100 @@ type EventPoolRegistryMetadataSet struct {
 	Metadata []types.U8
 	Topics   []types.Hash
 }
+
+// ScheduledUpdateDetails is the pending pool update that is stored in the `PoolSystem` pallet
+// after `UpdateStored` and applied once it's executed.
+type ScheduledUpdateDetails struct {
+	Changes     PoolChanges
+	SubmittedAt types.U64
+}
+
+// ExecutableAt returns the moment from which the update can be executed, given the runtime's
+// minimum update delay.
+func (s ScheduledUpdateDetails) ExecutableAt(minUpdateDelay types.U64) types.U64 {
+	return s.SubmittedAt + minUpdateDelay
+}
+
+// IsExecutable returns true if the minimum update delay has passed at the provided moment.
+func (s ScheduledUpdateDetails) IsExecutable(now, minUpdateDelay types.U64) bool {
+	return now >= s.ExecutableAt(minUpdateDelay)
+}
+
+type PoolChanges struct {
+	Tranches        Change[[]TrancheUpdate]
+	TrancheMetadata Change[[]TrancheMetadata]
+	MinEpochTime    Change[types.U64]
+	MaxNavAge       Change[types.U64]
+}
+
+type TrancheUpdate struct {
+	TrancheType TrancheType
+	Seniority   types.Option[types.U32]
+}
+
+// Change represents a value that is either left untouched or replaced by an update.
+type Change[T any] struct {
+	IsNoChange bool
+
+	IsNewValue bool
+	AsNewValue T
+}
+
+func NewChange[T any](value T) Change[T] {
+	return Change[T]{
+		IsNewValue: true,
+		AsNewValue: value,
+	}
+}
+
+func (c *Change[T]) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		c.IsNoChange = true
+
+		return nil
+	case 1:
+		c.IsNewValue = true
+
+		return decoder.Decode(&c.AsNewValue)
+	default:
+		return errors.New("unsupported change")
+	}
+}
+
+func (c Change[T]) Encode(encoder scale.Encoder) error {
+	switch {
+	case c.IsNoChange:
+		return encoder.PushByte(0)
+	case c.IsNewValue:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(c.AsNewValue)
+	default:
+		return errors.New("unsupported change")
+	}
+}
+
+const (
+	poolSystemPrefix          = "PoolSystem"
+	scheduledUpdateMethodName = "ScheduledUpdate"
+)
+
+// ScheduledUpdateStorageKey returns the storage key of the pending update of a pool.
+func ScheduledUpdateStorageKey(meta *types.Metadata, poolID types.U64) (types.StorageKey, error) {
+	encodedPoolID, err := codec.Encode(poolID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreateStorageKey(meta, poolSystemPrefix, scheduledUpdateMethodName, encodedPoolID)
+}
@@ -0,0 +1,81 @@
+package pools
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+)
+
+var (
+	changeFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(c *Change[types.U64], f fuzz.Continue) {
+			if f.RandBool() {
+				c.IsNoChange = true
+				return
+			}
+
+			c.IsNewValue = true
+			f.Fuzz(&c.AsNewValue)
+		}),
+	}
+)
+
+func TestChange_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[Change[types.U64]](t, 1000, changeFuzzOpts...)
+	AssertDecodeNilData[Change[types.U64]](t)
+}
+
+func TestChange_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{Input: Change[types.U64]{IsNoChange: true}, Expected: codec.MustHexDecodeString("0x00")},
+		{Input: NewChange[types.U64](3600), Expected: codec.MustHexDecodeString("0x01100e000000000000")},
+	})
+}
+
+func TestChange_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{Input: codec.MustHexDecodeString("0x00"), Expected: Change[types.U64]{IsNoChange: true}},
+		{Input: codec.MustHexDecodeString("0x01100e000000000000"), Expected: NewChange[types.U64](3600)},
+	})
+}
+
+var (
+	testScheduledUpdate = ScheduledUpdateDetails{
+		Changes: PoolChanges{
+			Tranches: NewChange([]TrancheUpdate{
+				{
+					TrancheType: TrancheType{IsResidual: true},
+					Seniority:   types.NewOption[types.U32](0),
+				},
+			}),
+			TrancheMetadata: Change[[]TrancheMetadata]{IsNoChange: true},
+			MinEpochTime:    NewChange[types.U64](3600),
+			MaxNavAge:       Change[types.U64]{IsNoChange: true},
+		},
+		SubmittedAt: 1000,
+	}
+)
+
+func TestScheduledUpdateDetails_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, testScheduledUpdate)
+}
+
+func TestScheduledUpdateDetails_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{
+			Input:    testScheduledUpdate,
+			Expected: codec.MustHexDecodeString("0x01040001000000000001100e00000000000000e803000000000000"),
+		},
+	})
+}
+
+func TestScheduledUpdateDetails_IsExecutable(t *testing.T) {
+	assert.Equal(t, types.U64(1600), testScheduledUpdate.ExecutableAt(600))
+	assert.False(t, testScheduledUpdate.IsExecutable(1599, 600))
+	assert.True(t, testScheduledUpdate.IsExecutable(1600, 600))
+}
@@ -2,6 +2,8 @@ package pools
 
 import (
 	"errors"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
@@ -58,7 +60,7 @@ type EventPoolSystemUpdated struct {
 }
 
 type PoolEssence struct {
-	Currency     types.CurrencyID
+	Currency     currency.CurrencyID
 	MaxReserve   types.U128
 	MaxNavAge    types.U64
 	MinEpochTime types.U64
@@ -130,6 +132,11 @@ type TrancheCurrency struct {
 	TrancheID [16]types.U8
 }
 
+// CurrencyID returns the currency ID of the tranche token.
+func (t TrancheCurrency) CurrencyID() currency.CurrencyID {
+	return currency.NewTrancheCurrencyID(t.PoolID, t.TrancheID)
+}
+
 type EpochSolution struct {
 	IsHealthy bool
 	AsHealthy HealthySolution
@@ -0,0 +1,108 @@
+package proxy
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	AddProxyCallName       = "Proxy.add_proxy"
+	RemoveProxyCallName    = "Proxy.remove_proxy"
+	CreatePureCallName     = "Proxy.create_pure"
+	ProxyCallName          = "Proxy.proxy"
+	ProxyAnnouncedCallName = "Proxy.proxy_announced"
+	AnnounceCallName       = "Proxy.announce"
+)
+
+// NewAddProxyCall returns the call that registers the delegate as a proxy of the caller.
+func NewAddProxyCall(
+	meta *types.Metadata,
+	delegate types.AccountID,
+	proxyType CentrifugeProxyType,
+	delay types.U32,
+) (types.Call, error) {
+	delegateAddress, err := types.NewMultiAddressFromAccountID(delegate[:])
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, AddProxyCallName, delegateAddress, proxyType, delay)
+}
+
+// NewRemoveProxyCall returns the call that unregisters the delegate as a proxy of the caller.
+func NewRemoveProxyCall(
+	meta *types.Metadata,
+	delegate types.AccountID,
+	proxyType CentrifugeProxyType,
+	delay types.U32,
+) (types.Call, error) {
+	delegateAddress, err := types.NewMultiAddressFromAccountID(delegate[:])
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, RemoveProxyCallName, delegateAddress, proxyType, delay)
+}
+
+// NewCreatePureCall returns the call that spawns a pure proxy account with the caller as its proxy.
+func NewCreatePureCall(
+	meta *types.Metadata,
+	proxyType CentrifugeProxyType,
+	delay types.U32,
+	index types.U16,
+) (types.Call, error) {
+	return types.NewCall(meta, CreatePureCallName, proxyType, delay, index)
+}
+
+// NewProxyCall returns the call that dispatches the call on behalf of the real account.
+func NewProxyCall(
+	meta *types.Metadata,
+	real types.AccountID,
+	forceProxyType types.Option[CentrifugeProxyType],
+	call types.Call,
+) (types.Call, error) {
+	realAddress, err := types.NewMultiAddressFromAccountID(real[:])
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, ProxyCallName, realAddress, forceProxyType, call)
+}
+
+// NewProxyAnnouncedCall returns the call that dispatches a call, previously announced by the delegate,
+// on behalf of the real account.
+func NewProxyAnnouncedCall(
+	meta *types.Metadata,
+	delegate types.AccountID,
+	real types.AccountID,
+	forceProxyType types.Option[CentrifugeProxyType],
+	call types.Call,
+) (types.Call, error) {
+	delegateAddress, err := types.NewMultiAddressFromAccountID(delegate[:])
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	realAddress, err := types.NewMultiAddressFromAccountID(real[:])
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, ProxyAnnouncedCallName, delegateAddress, realAddress, forceProxyType, call)
+}
+
+// NewAnnounceCall returns the call that announces the hash of a call that the caller, as a delayed proxy,
+// will dispatch on behalf of the real account with a `Proxy.proxy_announced` call.
+func NewAnnounceCall(meta *types.Metadata, real types.AccountID, callHash types.Hash) (types.Call, error) {
+	realAddress, err := types.NewMultiAddressFromAccountID(real[:])
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, AnnounceCallName, realAddress, callHash)
+}
@@ -0,0 +1,88 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/internal/testmeta"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testMeta = testmeta.NewMetadata(
+		testmeta.Pallet{Name: "System", Index: 0, Calls: []string{"remark"}},
+		testmeta.Pallet{
+			Name:  "Proxy",
+			Index: 93,
+			Calls: []string{
+				"proxy",
+				"add_proxy",
+				"remove_proxy",
+				"remove_proxies",
+				"create_pure",
+				"kill_pure",
+				"announce",
+				"remove_announcement",
+				"reject_announcement",
+				"proxy_announced",
+			},
+		},
+	)
+
+	testAccount = types.AccountID{1}
+)
+
+func TestNewAddProxyCall(t *testing.T) {
+	call, err := NewAddProxyCall(testMeta, testAccount, Borrow, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 93, MethodIndex: 1}, call.CallIndex)
+
+	expectedArgs := append([]byte{0x00}, testAccount[:]...)
+	expectedArgs = append(expectedArgs, codec.MustHexDecodeString("0x050a000000")...)
+
+	assert.Equal(t, types.Args(expectedArgs), call.Args)
+
+	call, err = NewRemoveProxyCall(testMeta, testAccount, Borrow, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 93, MethodIndex: 2}, call.CallIndex)
+	assert.Equal(t, types.Args(expectedArgs), call.Args)
+}
+
+func TestNewCreatePureCall(t *testing.T) {
+	call, err := NewCreatePureCall(testMeta, Any, 0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 93, MethodIndex: 4}, call.CallIndex)
+	assert.Equal(t, types.Args(codec.MustHexDecodeString("0x00000000000100")), call.Args)
+}
+
+func TestNewAnnounceCall(t *testing.T) {
+	callHash := types.NewHash([]byte{0xab})
+
+	call, err := NewAnnounceCall(testMeta, testAccount, callHash)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 93, MethodIndex: 6}, call.CallIndex)
+
+	expectedArgs := append([]byte{0x00}, testAccount[:]...)
+	expectedArgs = append(expectedArgs, callHash[:]...)
+
+	assert.Equal(t, types.Args(expectedArgs), call.Args)
+}
+
+func TestNewProxyCall(t *testing.T) {
+	remark, err := types.NewCall(testMeta, "System.remark", []byte{0xab})
+	assert.NoError(t, err)
+
+	call, err := NewProxyCall(testMeta, testAccount, types.NewOption(Borrow), remark)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 93, MethodIndex: 0}, call.CallIndex)
+
+	expectedArgs := append([]byte{0x00}, testAccount[:]...)
+	expectedArgs = append(expectedArgs, codec.MustHexDecodeString("0x01050000"+"04ab")...)
+
+	assert.Equal(t, types.Args(expectedArgs), call.Args)
+
+	call, err = NewProxyAnnouncedCall(testMeta, types.AccountID{2}, testAccount, types.Option[CentrifugeProxyType]{}, remark)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 93, MethodIndex: 9}, call.CallIndex)
+}
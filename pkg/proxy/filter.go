@@ -0,0 +1,230 @@
+package proxy
+
+import (
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Call describes a runtime call for the purpose of proxy filtering.
+//
+// Calls that dispatch other calls, such as `Utility.batch` or `Proxy.proxy`, hold the dispatched calls in Calls.
+type Call struct {
+	Pallet string
+	Name   string
+
+	// ProxyType is the proxy type argument of `Proxy.add_proxy` and `Proxy.remove_proxy`,
+	// or the forced proxy type of `Proxy.proxy`.
+	ProxyType types.Option[CentrifugeProxyType]
+
+	Calls []Call
+}
+
+// NewFilterCall returns the call with the provided `Pallet.call_name` name and nested calls.
+func NewFilterCall(name string, calls ...Call) Call {
+	pallet, callName, _ := strings.Cut(name, ".")
+
+	return Call{
+		Pallet: pallet,
+		Name:   callName,
+		Calls:  calls,
+	}
+}
+
+// WithProxyType returns a copy of the call with the proxy type argument set.
+func (c Call) WithProxyType(proxyType CentrifugeProxyType) Call {
+	c.ProxyType = types.NewOption(proxyType)
+
+	return c
+}
+
+func (c Call) is(pallet string, names ...string) bool {
+	if c.Pallet != pallet {
+		return false
+	}
+
+	if len(names) == 0 {
+		return true
+	}
+
+	for _, name := range names {
+		if c.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+const (
+	proxyPallet   = "Proxy"
+	utilityPallet = "Utility"
+)
+
+var (
+	// utilityDispatchCalls are the utility calls that dispatch their nested calls with the filtered origin.
+	utilityDispatchCalls = []string{"batch", "batch_all", "force_batch", "as_derivative"}
+
+	nonTransferPallets = map[string]struct{}{
+		"System":            {},
+		"ParachainSystem":   {},
+		"Timestamp":         {},
+		"CollatorSelection": {},
+		"Authorship":        {},
+		"Session":           {},
+		"Multisig":          {},
+		"Proxy":             {},
+		"Utility":           {},
+		"Scheduler":         {},
+		"Council":           {},
+		"Elections":         {},
+		"Democracy":         {},
+		"Identity":          {},
+		"Preimage":          {},
+		"Fees":              {},
+		"Anchor":            {},
+		"CrowdloanClaim":    {},
+		"CrowdloanReward":   {},
+		"PoolSystem":        {},
+		"Permissions":       {},
+		"CollatorAllowlist": {},
+		"BlockRewards":      {},
+	}
+
+	nonTransferCalls = map[string][]string{
+		"Vesting": {"vest", "vest_other"},
+		"Loans": {
+			"create",
+			"write_off",
+			"admin_write_off",
+			"propose_loan_mutation",
+			"apply_loan_mutation",
+			"close",
+			"propose_write_off_policy",
+			"apply_write_off_policy",
+			"update_portfolio_valuation",
+		},
+		"Investments":  {"collect_investments_for", "collect_redemptions_for"},
+		"PoolRegistry": {"execute_update"},
+	}
+
+	epochCalls = []string{"close_epoch", "submit_solution", "execute_epoch"}
+)
+
+// Filter returns true if the runtime's `InstanceFilter` of the proxy type allows the call itself,
+// without considering nested calls or the checks done by the proxy pallet. See Allows for the complete check.
+func (pt CentrifugeProxyType) Filter(c Call) bool {
+	switch pt {
+	case Any:
+		return true
+	case NonTransfer:
+		if _, ok := nonTransferPallets[c.Pallet]; ok {
+			return true
+		}
+
+		names, ok := nonTransferCalls[c.Pallet]
+
+		return ok && c.is(c.Pallet, names...)
+	case Governance:
+		return c.is("Democracy") || c.is("Council") || c.is("Elections") || c.is(utilityPallet)
+	case NonProxy:
+		return c.is(proxyPallet, "proxy") || !c.is(proxyPallet)
+	case Borrow:
+		return c.is(
+			"Loans",
+			"create",
+			"borrow",
+			"repay",
+			"write_off",
+			"apply_loan_mutation",
+			"close",
+			"apply_write_off_policy",
+			"update_portfolio_valuation",
+		) ||
+			c.is("PoolSystem", epochCalls...) ||
+			c.is(utilityPallet, "batch_all", "batch")
+	case Invest:
+		return c.is(
+			"Investments",
+			"update_invest_order",
+			"update_redeem_order",
+			"collect_investments",
+			"collect_redemptions",
+		) ||
+			c.is("Loans", "update_portfolio_valuation") ||
+			c.is("PoolSystem", epochCalls...) ||
+			c.is(utilityPallet, "batch_all", "batch")
+	case ProxyManagement:
+		return c.is(proxyPallet)
+	case KeystoreManagement:
+		return c.is("Keystore", "add_keys", "revoke_keys")
+	case PodOperation:
+		return c.is("Uniques") || c.is("Anchor") || c.is(utilityPallet, "batch_all")
+	case PermissionManagement:
+		return c.is("Permissions", "add", "remove")
+	default:
+		// Staking is deprecated and PodAuth is only used to authenticate with the Centrifuge POD.
+		return false
+	}
+}
+
+// IsSuperset returns true if the proxy type allows at least the calls allowed by the other proxy type.
+func (pt CentrifugeProxyType) IsSuperset(o CentrifugeProxyType) bool {
+	switch {
+	case pt == o:
+		return true
+	case pt == Any:
+		return true
+	case o == Any:
+		return false
+	case pt == NonTransfer:
+		return true
+	default:
+		return false
+	}
+}
+
+// Allows returns true if a proxy of the proxy type is able to dispatch the call, including its nested calls.
+//
+// Besides the `InstanceFilter`, this applies the checks of the proxy pallet, which prevent a proxy from adding or
+// removing proxies with more permissions than it has. Calls nested in utility calls are dispatched with the same
+// filter. Calls nested in `Proxy.proxy` are dispatched by another proxy relationship, so they're only checked
+// if the forced proxy type is provided.
+func (pt CentrifugeProxyType) Allows(c Call) bool {
+	if c.is(proxyPallet, "add_proxy", "remove_proxy") {
+		if ok, proxyType := c.ProxyType.Unwrap(); ok && !pt.IsSuperset(proxyType) {
+			return false
+		}
+	}
+
+	if c.is(proxyPallet, "remove_proxies", "kill_pure", "kill_anonymous") && pt != Any {
+		return false
+	}
+
+	if !pt.Filter(c) {
+		return false
+	}
+
+	switch {
+	case c.is(utilityPallet, utilityDispatchCalls...):
+		for _, nested := range c.Calls {
+			if !pt.Allows(nested) {
+				return false
+			}
+		}
+	case c.is(proxyPallet, "proxy", "proxy_announced"):
+		ok, proxyType := c.ProxyType.Unwrap()
+
+		if !ok {
+			return true
+		}
+
+		for _, nested := range c.Calls {
+			if !proxyType.Allows(nested) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,83 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCentrifugeProxyType_Filter(t *testing.T) {
+	borrow := NewFilterCall("Loans.borrow")
+	transfer := NewFilterCall("Balances.transfer")
+	invest := NewFilterCall("Investments.update_invest_order")
+	addKeys := NewFilterCall("Keystore.add_keys")
+	addPermission := NewFilterCall("Permissions.add")
+
+	assert.True(t, Any.Filter(transfer))
+
+	assert.False(t, NonTransfer.Filter(transfer))
+	assert.False(t, NonTransfer.Filter(borrow))
+	assert.True(t, NonTransfer.Filter(NewFilterCall("Loans.create")))
+	assert.True(t, NonTransfer.Filter(addPermission))
+
+	assert.True(t, Borrow.Filter(borrow))
+	assert.True(t, Borrow.Filter(NewFilterCall("PoolSystem.close_epoch")))
+	assert.False(t, Borrow.Filter(invest))
+
+	assert.True(t, Invest.Filter(invest))
+	assert.False(t, Invest.Filter(borrow))
+
+	assert.True(t, KeystoreManagement.Filter(addKeys))
+	assert.False(t, KeystoreManagement.Filter(NewFilterCall("Keystore.set_deposit")))
+
+	assert.True(t, PermissionManagement.Filter(addPermission))
+	assert.False(t, PermissionManagement.Filter(NewFilterCall("Permissions.purge")))
+
+	assert.True(t, PodOperation.Filter(NewFilterCall("Anchor.commit")))
+	assert.False(t, PodOperation.Filter(NewFilterCall("Utility.batch")))
+
+	assert.True(t, NonProxy.Filter(NewFilterCall("Proxy.proxy")))
+	assert.False(t, NonProxy.Filter(NewFilterCall("Proxy.add_proxy")))
+	assert.True(t, NonProxy.Filter(transfer))
+
+	assert.False(t, Staking.Filter(transfer))
+	assert.False(t, PodAuth.Filter(addKeys))
+}
+
+func TestCentrifugeProxyType_IsSuperset(t *testing.T) {
+	for pt := range proxyTypeMap {
+		assert.True(t, pt.IsSuperset(pt))
+		assert.True(t, Any.IsSuperset(pt))
+
+		if pt != Any {
+			assert.False(t, pt.IsSuperset(Any))
+			assert.True(t, NonTransfer.IsSuperset(pt))
+		}
+	}
+
+	assert.False(t, Borrow.IsSuperset(Invest))
+}
+
+func TestCentrifugeProxyType_Allows(t *testing.T) {
+	borrow := NewFilterCall("Loans.borrow")
+	repay := NewFilterCall("Loans.repay")
+	transfer := NewFilterCall("Balances.transfer")
+
+	assert.True(t, Borrow.Allows(NewFilterCall("Utility.batch_all", borrow, repay)))
+	assert.False(t, Borrow.Allows(NewFilterCall("Utility.batch_all", borrow, transfer)))
+	assert.False(t, Borrow.Allows(NewFilterCall("Utility.batch", NewFilterCall("Utility.batch_all", transfer))))
+
+	// Proxies can't add proxies with more permissions.
+	assert.True(t, NonTransfer.Allows(NewFilterCall("Proxy.add_proxy").WithProxyType(Borrow)))
+	assert.False(t, NonTransfer.Allows(NewFilterCall("Proxy.add_proxy").WithProxyType(Any)))
+	assert.False(t, ProxyManagement.Allows(NewFilterCall("Proxy.add_proxy").WithProxyType(Borrow)))
+	assert.True(t, ProxyManagement.Allows(NewFilterCall("Proxy.add_proxy").WithProxyType(ProxyManagement)))
+	assert.False(t, NonTransfer.Allows(NewFilterCall("Proxy.remove_proxies")))
+	assert.True(t, Any.Allows(NewFilterCall("Proxy.remove_proxies")))
+
+	// Calls nested in proxy calls are checked against the forced proxy type.
+	assert.True(t, NonTransfer.Allows(NewFilterCall("Proxy.proxy", transfer)))
+	assert.False(t, NonTransfer.Allows(NewFilterCall("Proxy.proxy", transfer).WithProxyType(Borrow)))
+	assert.True(t, NonTransfer.Allows(NewFilterCall("Proxy.proxy", borrow).WithProxyType(Borrow)))
+	assert.False(t, Borrow.Allows(NewFilterCall("Proxy.proxy", borrow).WithProxyType(Borrow)))
+}
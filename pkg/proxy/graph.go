@@ -0,0 +1,349 @@
+package proxy
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/centrifuge/chain-custom-types/pkg/phase"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Edge is a proxy relationship, the delegate is able to dispatch calls allowed by the proxy type
+// on behalf of the delegator.
+type Edge struct {
+	Delegator types.AccountID
+	Delegate  types.AccountID
+	ProxyType CentrifugeProxyType
+	Delay     types.U32
+}
+
+// reaches returns true if the delegate of the edge is able to use the next edge, whose delegate is the
+// delegator of this edge. Delayed proxies require their delegate to announce the call first.
+func (e Edge) reaches(next Edge) bool {
+	call := NewFilterCall(ProxyCallName)
+
+	if next.Delay > 0 {
+		call = NewFilterCall(AnnounceCallName)
+	}
+
+	return e.ProxyType.Filter(call)
+}
+
+func (e Edge) definition() ProxyDefinition {
+	return ProxyDefinition{
+		Delegate:  e.Delegate,
+		ProxyType: e.ProxyType,
+		Delay:     e.Delay,
+	}
+}
+
+// Path is a chain of proxy relationships, starting with the edge of the acting account
+// and ending with the edge of the account that is acted for.
+type Path []Edge
+
+// Actor returns the account that is able to act through the path.
+func (p Path) Actor() types.AccountID {
+	return p[0].Delegate
+}
+
+// Target returns the account that is acted for.
+func (p Path) Target() types.AccountID {
+	return p[len(p)-1].Delegator
+}
+
+// ProxyType returns the proxy type that filters the calls dispatched for the target.
+//
+// Every proxy call creates a new origin, so only the last proxy type of the path restricts the calls
+// dispatched for the target, while the others only need to allow the nested proxy calls.
+func (p Path) ProxyType() CentrifugeProxyType {
+	return p[len(p)-1].ProxyType
+}
+
+// IsDelayed returns true if any of the proxies in the path requires an announcement.
+func (p Path) IsDelayed() bool {
+	for _, edge := range p {
+		if edge.Delay > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Actor is an account that is able to act for another account, directly or through a chain of proxies.
+type Actor struct {
+	Account types.AccountID
+	// ProxyTypes holds the proxy types that the account is able to act with, over all the paths.
+	ProxyTypes []CentrifugeProxyType
+}
+
+// Graph holds the proxy relationships of the `proxy` pallet.
+type Graph struct {
+	proxies map[types.AccountID][]ProxyDefinition
+}
+
+func NewGraph() *Graph {
+	return &Graph{
+		proxies: make(map[types.AccountID][]ProxyDefinition),
+	}
+}
+
+// SetProxies replaces the proxies of the delegator with the ones stored in `Proxy.Proxies`.
+//
+// `Proxy.remove_proxies` and `Proxy.kill_pure` don't emit events and the proxies of pure accounts are only
+// reported with an event that lacks the delay, so the graph should be loaded from storage whenever possible.
+func (g *Graph) SetProxies(delegator types.AccountID, proxies Proxies) {
+	if len(proxies.Definitions) == 0 {
+		delete(g.proxies, delegator)
+
+		return
+	}
+
+	definitions := make([]ProxyDefinition, 0, len(proxies.Definitions))
+
+	for _, definition := range proxies.Definitions {
+		definitions = insertDefinition(definitions, definition)
+	}
+
+	g.proxies[delegator] = definitions
+}
+
+// AddProxy adds a proxy of the delegator, adding a proxy that already exists is a no-op.
+func (g *Graph) AddProxy(delegator types.AccountID, definition ProxyDefinition) {
+	g.proxies[delegator] = insertDefinition(g.proxies[delegator], definition)
+}
+
+// RemoveProxy removes a proxy of the delegator, removing a proxy that doesn't exist is a no-op.
+func (g *Graph) RemoveProxy(delegator types.AccountID, definition ProxyDefinition) {
+	definitions := g.proxies[delegator]
+
+	for i, existing := range definitions {
+		if existing != definition {
+			continue
+		}
+
+		definitions = append(definitions[:i:i], definitions[i+1:]...)
+
+		break
+	}
+
+	if len(definitions) == 0 {
+		delete(g.proxies, delegator)
+
+		return
+	}
+
+	g.proxies[delegator] = definitions
+}
+
+// ProcessEvents applies the `Proxy_ProxyAdded` and `Proxy_ProxyRemoved` events of a block in the order
+// of their phase. The events decoded by GSRPC are converted with NewEventProxyProxyAdded and
+// NewEventProxyProxyRemoved. Blocks must be processed in order.
+func (g *Graph) ProcessEvents(added []EventProxyProxyAdded, removed []EventProxyProxyRemoved) {
+	evts := make([]phase.Event, 0, len(added)+len(removed))
+
+	for _, event := range added {
+		event := event
+
+		evts = append(evts, phase.Event{Phase: event.Phase, Apply: func() error {
+			g.AddProxy(event.Delegator, ProxyDefinition{
+				Delegate:  event.Delegatee,
+				ProxyType: event.ProxyType,
+				Delay:     event.Delay,
+			})
+
+			return nil
+		}})
+	}
+
+	for _, event := range removed {
+		event := event
+
+		evts = append(evts, phase.Event{Phase: event.Phase, Apply: func() error {
+			g.RemoveProxy(event.Delegator, ProxyDefinition{
+				Delegate:  event.Delegatee,
+				ProxyType: event.ProxyType,
+				Delay:     event.Delay,
+			})
+
+			return nil
+		}})
+	}
+
+	// The proxy events always apply.
+	_ = phase.Apply(evts)
+}
+
+// Edges returns all the proxy relationships, sorted by delegator.
+func (g *Graph) Edges() []Edge {
+	var res []Edge
+
+	for delegator, definitions := range g.proxies {
+		for _, definition := range definitions {
+			res = append(res, newEdge(delegator, definition))
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if c := bytes.Compare(res[i].Delegator[:], res[j].Delegator[:]); c != 0 {
+			return c < 0
+		}
+
+		return definitionLess(res[i].definition(), res[j].definition())
+	})
+
+	return res
+}
+
+// Paths returns all the paths through which other accounts are able to act for the target,
+// sorted by length and actor. Paths don't visit an account twice.
+func (g *Graph) Paths(target types.AccountID) []Path {
+	var res []Path
+
+	visited := map[types.AccountID]struct{}{
+		target: {},
+	}
+
+	var walk func(account types.AccountID, suffix Path)
+
+	walk = func(account types.AccountID, suffix Path) {
+		for _, definition := range g.proxies[account] {
+			edge := newEdge(account, definition)
+
+			if len(suffix) > 0 && !edge.reaches(suffix[0]) {
+				continue
+			}
+
+			if _, ok := visited[edge.Delegate]; ok {
+				continue
+			}
+
+			path := append(Path{edge}, suffix...)
+
+			res = append(res, path)
+
+			visited[edge.Delegate] = struct{}{}
+
+			walk(edge.Delegate, path)
+
+			delete(visited, edge.Delegate)
+		}
+	}
+
+	walk(target, nil)
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if len(res[i]) != len(res[j]) {
+			return len(res[i]) < len(res[j])
+		}
+
+		actorI, actorJ := res[i].Actor(), res[j].Actor()
+
+		return bytes.Compare(actorI[:], actorJ[:]) < 0
+	})
+
+	return res
+}
+
+// Actors returns the accounts that are able to act for the target, sorted by account.
+func (g *Graph) Actors(target types.AccountID) []Actor {
+	proxyTypes := make(map[types.AccountID]map[CentrifugeProxyType]struct{})
+
+	for _, path := range g.Paths(target) {
+		actor := path.Actor()
+
+		if _, ok := proxyTypes[actor]; !ok {
+			proxyTypes[actor] = make(map[CentrifugeProxyType]struct{})
+		}
+
+		proxyTypes[actor][path.ProxyType()] = struct{}{}
+	}
+
+	res := make([]Actor, 0, len(proxyTypes))
+
+	for account, set := range proxyTypes {
+		actor := Actor{Account: account}
+
+		for proxyType := range set {
+			actor.ProxyTypes = append(actor.ProxyTypes, proxyType)
+		}
+
+		sort.Slice(actor.ProxyTypes, func(i, j int) bool {
+			return actor.ProxyTypes[i] < actor.ProxyTypes[j]
+		})
+
+		res = append(res, actor)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return bytes.Compare(res[i].Account[:], res[j].Account[:]) < 0
+	})
+
+	return res
+}
+
+// EscalationPaths returns the paths through which accounts that aren't direct `Any` proxies of the target
+// are able to act for it with the `Any` proxy type.
+func (g *Graph) EscalationPaths(target types.AccountID) []Path {
+	direct := make(map[types.AccountID]struct{})
+
+	for _, definition := range g.proxies[target] {
+		if definition.ProxyType == Any {
+			direct[definition.Delegate] = struct{}{}
+		}
+	}
+
+	var res []Path
+
+	for _, path := range g.Paths(target) {
+		if len(path) < 2 || path.ProxyType() != Any {
+			continue
+		}
+
+		if _, ok := direct[path.Actor()]; ok {
+			continue
+		}
+
+		res = append(res, path)
+	}
+
+	return res
+}
+
+func newEdge(delegator types.AccountID, definition ProxyDefinition) Edge {
+	return Edge{
+		Delegator: delegator,
+		Delegate:  definition.Delegate,
+		ProxyType: definition.ProxyType,
+		Delay:     definition.Delay,
+	}
+}
+
+// insertDefinition keeps the definitions sorted, similarly to the proxy pallet.
+func insertDefinition(definitions []ProxyDefinition, definition ProxyDefinition) []ProxyDefinition {
+	i := sort.Search(len(definitions), func(i int) bool {
+		return !definitionLess(definitions[i], definition)
+	})
+
+	if i < len(definitions) && definitions[i] == definition {
+		return definitions
+	}
+
+	definitions = append(definitions, ProxyDefinition{})
+	copy(definitions[i+1:], definitions[i:])
+	definitions[i] = definition
+
+	return definitions
+}
+
+func definitionLess(a, b ProxyDefinition) bool {
+	if c := bytes.Compare(a.Delegate[:], b.Delegate[:]); c != 0 {
+		return c < 0
+	}
+
+	if a.ProxyType != b.ProxyType {
+		return a.ProxyType < b.ProxyType
+	}
+
+	return a.Delay < b.Delay
+}
@@ -0,0 +1,146 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	graphTreasury  = types.AccountID{0x10}
+	graphMultisig  = types.AccountID{0x11}
+	graphBot       = types.AccountID{0x12}
+	graphDelayed   = types.AccountID{0x13}
+	graphNonProxy  = types.AccountID{0x14}
+	graphBorrower  = types.AccountID{0x15}
+	graphManager   = types.AccountID{0x16}
+	graphAnnouncer = types.AccountID{0x17}
+	graphBlocked   = types.AccountID{0x18}
+)
+
+func newTestGraph() *Graph {
+	g := NewGraph()
+
+	g.SetProxies(graphTreasury, Proxies{
+		Definitions: []ProxyDefinition{
+			{Delegate: graphMultisig, ProxyType: Any},
+			{Delegate: graphBot, ProxyType: Invest},
+			{Delegate: graphDelayed, ProxyType: Any, Delay: 10},
+		},
+	})
+
+	g.AddProxy(graphMultisig, ProxyDefinition{Delegate: graphNonProxy, ProxyType: NonProxy})
+	g.AddProxy(graphMultisig, ProxyDefinition{Delegate: graphBorrower, ProxyType: Borrow})
+	g.AddProxy(graphBot, ProxyDefinition{Delegate: graphManager, ProxyType: ProxyManagement})
+	g.AddProxy(graphDelayed, ProxyDefinition{Delegate: graphAnnouncer, ProxyType: ProxyManagement})
+	g.AddProxy(graphDelayed, ProxyDefinition{Delegate: graphBlocked, ProxyType: NonProxy})
+	// Cycle back to the multisig.
+	g.AddProxy(graphNonProxy, ProxyDefinition{Delegate: graphMultisig, ProxyType: Any})
+
+	return g
+}
+
+func TestGraph_Actors(t *testing.T) {
+	g := newTestGraph()
+
+	assert.Equal(t, []Actor{
+		{Account: graphMultisig, ProxyTypes: []CentrifugeProxyType{Any}},
+		{Account: graphBot, ProxyTypes: []CentrifugeProxyType{Invest}},
+		{Account: graphDelayed, ProxyTypes: []CentrifugeProxyType{Any}},
+		{Account: graphNonProxy, ProxyTypes: []CentrifugeProxyType{Any}},
+		{Account: graphManager, ProxyTypes: []CentrifugeProxyType{Invest}},
+		{Account: graphAnnouncer, ProxyTypes: []CentrifugeProxyType{Any}},
+	}, g.Actors(graphTreasury))
+
+	assert.Equal(t, []Actor{
+		{Account: graphMultisig, ProxyTypes: []CentrifugeProxyType{Any}},
+	}, g.Actors(graphNonProxy))
+}
+
+func TestGraph_Paths(t *testing.T) {
+	g := newTestGraph()
+
+	paths := g.Paths(graphTreasury)
+
+	assert.Len(t, paths, 6)
+
+	for _, path := range paths[:3] {
+		assert.Len(t, path, 1)
+	}
+
+	last := paths[len(paths)-1]
+
+	assert.Equal(t, graphAnnouncer, last.Actor())
+	assert.Equal(t, graphTreasury, last.Target())
+	assert.Equal(t, Any, last.ProxyType())
+	assert.True(t, last.IsDelayed())
+}
+
+func TestGraph_EscalationPaths(t *testing.T) {
+	g := newTestGraph()
+
+	assert.Equal(t, []Path{
+		{
+			{Delegator: graphMultisig, Delegate: graphNonProxy, ProxyType: NonProxy},
+			{Delegator: graphTreasury, Delegate: graphMultisig, ProxyType: Any},
+		},
+		{
+			{Delegator: graphDelayed, Delegate: graphAnnouncer, ProxyType: ProxyManagement},
+			{Delegator: graphTreasury, Delegate: graphDelayed, ProxyType: Any, Delay: 10},
+		},
+	}, g.EscalationPaths(graphTreasury))
+
+	// A direct Any proxy is not an escalation, even if it can also act through a chain.
+	g.AddProxy(graphTreasury, ProxyDefinition{Delegate: graphNonProxy, ProxyType: Any})
+
+	paths := g.EscalationPaths(graphTreasury)
+
+	assert.Len(t, paths, 1)
+	assert.Equal(t, graphAnnouncer, paths[0].Actor())
+}
+
+func TestGraph_ProcessEvents(t *testing.T) {
+	g := NewGraph()
+
+	definition := ProxyDefinition{Delegate: graphMultisig, ProxyType: Any}
+
+	g.ProcessEvents(
+		[]EventProxyProxyAdded{
+			{
+				Phase:     types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1},
+				Delegator: graphTreasury,
+				Delegatee: graphMultisig,
+				ProxyType: Any,
+			},
+			{
+				Phase:     types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 3},
+				Delegator: graphTreasury,
+				Delegatee: graphBot,
+				ProxyType: Invest,
+			},
+		},
+		[]EventProxyProxyRemoved{
+			{
+				Phase:     types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 2},
+				Delegator: graphTreasury,
+				Delegatee: graphMultisig,
+				ProxyType: Any,
+			},
+		},
+	)
+
+	assert.Equal(t, []Edge{
+		{Delegator: graphTreasury, Delegate: graphBot, ProxyType: Invest},
+	}, g.Edges())
+
+	g.AddProxy(graphTreasury, definition)
+	g.AddProxy(graphTreasury, definition)
+
+	assert.Len(t, g.Edges(), 2)
+
+	g.RemoveProxy(graphTreasury, definition)
+	g.RemoveProxy(graphTreasury, ProxyDefinition{Delegate: graphBot, ProxyType: Invest})
+
+	assert.Empty(t, g.Edges())
+}
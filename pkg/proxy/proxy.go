@@ -1,10 +1,94 @@
 package proxy
 
 import (
-	"fmt"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
 
+type EventProxyPureCreated struct {
+	Phase               types.Phase
+	Pure                types.AccountID
+	Who                 types.AccountID
+	ProxyType           CentrifugeProxyType
+	DisambiguationIndex types.U16
+	Topics              []types.Hash
+}
+
+type EventProxyProxyAdded struct {
+	Phase     types.Phase
+	Delegator types.AccountID
+	Delegatee types.AccountID
+	ProxyType CentrifugeProxyType
+	Delay     types.U32
+	Topics    []types.Hash
+}
+
+type EventProxyProxyRemoved struct {
+	Phase     types.Phase
+	Delegator types.AccountID
+	Delegatee types.AccountID
+	ProxyType CentrifugeProxyType
+	Delay     types.U32
+	Topics    []types.Hash
+}
+
+// The proxy events are decoded by GSRPC, in the `Proxy_*` fields of types.EventRecords, with a raw proxy type.
+// The following functions convert them to the events of this package, mapping the proxy type
+// with the table of the connected runtime, see UseProxyTypeTable.
+
+func NewEventProxyPureCreated(event types.EventProxyPureCreated) (EventProxyPureCreated, error) {
+	proxyType, err := proxyTypeFromIndex(uint8(event.ProxyType))
+
+	if err != nil {
+		return EventProxyPureCreated{}, err
+	}
+
+	return EventProxyPureCreated{
+		Phase:               event.Phase,
+		Pure:                event.Pure,
+		Who:                 event.Who,
+		ProxyType:           proxyType,
+		DisambiguationIndex: event.DisambiguationIndex,
+		Topics:              event.Topics,
+	}, nil
+}
+
+func NewEventProxyProxyAdded(event types.EventProxyProxyAdded) (EventProxyProxyAdded, error) {
+	proxyType, err := proxyTypeFromIndex(uint8(event.ProxyType))
+
+	if err != nil {
+		return EventProxyProxyAdded{}, err
+	}
+
+	return EventProxyProxyAdded{
+		Phase:     event.Phase,
+		Delegator: event.Delegator,
+		Delegatee: event.Delegatee,
+		ProxyType: proxyType,
+		Delay:     event.Delay,
+		Topics:    event.Topics,
+	}, nil
+}
+
+// NewEventProxyProxyRemoved converts the event, whose delay is decoded in the BlockNumber field by GSRPC.
+func NewEventProxyProxyRemoved(event types.EventProxyProxyRemoved) (EventProxyProxyRemoved, error) {
+	proxyType, err := proxyTypeFromIndex(uint8(event.ProxyType))
+
+	if err != nil {
+		return EventProxyProxyRemoved{}, err
+	}
+
+	return EventProxyProxyRemoved{
+		Phase:     event.Phase,
+		Delegator: event.Delegator,
+		Delegatee: event.Delegatee,
+		ProxyType: proxyType,
+		Delay:     event.BlockNumber,
+		Topics:    event.Topics,
+	}, nil
+}
+
 type CentrifugeProxyType uint8
 
 const (
@@ -76,10 +160,10 @@ func (pt *CentrifugeProxyType) Decode(decoder scale.Decoder) error {
 		return err
 	}
 
-	pb := CentrifugeProxyType(b)
+	pb, err := proxyTypeFromIndex(b)
 
-	if _, ok := proxyTypeMap[pb]; !ok {
-		return fmt.Errorf("unknown ProxyType enum: %v", pb)
+	if err != nil {
+		return err
 	}
 
 	*pt = pb
@@ -88,5 +172,64 @@ func (pt *CentrifugeProxyType) Decode(decoder scale.Decoder) error {
 }
 
 func (pt CentrifugeProxyType) Encode(encoder scale.Encoder) error {
-	return encoder.PushByte(byte(pt))
+	index, err := proxyTypeIndex(pt)
+
+	if err != nil {
+		return err
+	}
+
+	return encoder.PushByte(index)
+}
+
+type ProxyDefinition struct {
+	Delegate  types.AccountID
+	ProxyType CentrifugeProxyType
+	Delay     types.U32
+}
+
+// Proxies is the value of the `Proxies` storage, which holds the proxies of an account and the reserved deposit.
+type Proxies struct {
+	Definitions []ProxyDefinition
+	Deposit     types.U128
+}
+
+type Announcement struct {
+	Real     types.AccountID
+	CallHash types.Hash
+	Height   types.U32
+}
+
+// Announcements is the value of the `Announcements` storage, which holds the announcements of a proxy
+// and the reserved deposit.
+type Announcements struct {
+	Announcements []Announcement
+	Deposit       types.U128
+}
+
+const (
+	proxyPrefix             = "Proxy"
+	proxiesMethodName       = "Proxies"
+	announcementsMethodName = "Announcements"
+)
+
+// ProxiesStorageKey returns the storage key of the proxies of the delegator.
+func ProxiesStorageKey(meta *types.Metadata, delegator types.AccountID) (types.StorageKey, error) {
+	encodedDelegator, err := codec.Encode(delegator)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreateStorageKey(meta, proxyPrefix, proxiesMethodName, encodedDelegator)
+}
+
+// AnnouncementsStorageKey returns the storage key of the announcements made by the proxy.
+func AnnouncementsStorageKey(meta *types.Metadata, proxy types.AccountID) (types.StorageKey, error) {
+	encodedProxy, err := codec.Encode(proxy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreateStorageKey(meta, proxyPrefix, announcementsMethodName, encodedProxy)
 }
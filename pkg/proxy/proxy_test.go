@@ -1,15 +1,20 @@
 package proxy
 
 import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
 	fuzz "github.com/google/gofuzz"
-	"testing"
+	"github.com/stretchr/testify/assert"
 )
 
 var (
 	proxyTypeFuzzOpts = []FuzzOpt{
 		WithFuzzFuncs(func(p *CentrifugeProxyType, c fuzz.Continue) {
-			*p = CentrifugeProxyType(c.Intn(12))
+			*p = CentrifugeProxyType(c.Intn(len(proxyTypeMap)))
 		}),
 	}
 )
@@ -19,3 +24,68 @@ func TestProxyTypeEncodeDecode(t *testing.T) {
 	AssertDecodeNilData[CentrifugeProxyType](t)
 	AssertEncodeEmptyObj[CentrifugeProxyType](t, 1)
 }
+
+var (
+	testProxies = Proxies{
+		Definitions: []ProxyDefinition{
+			{Delegate: types.AccountID{1}, ProxyType: Borrow, Delay: 10},
+		},
+		Deposit: types.NewU128(*big.NewInt(100)),
+	}
+	proxiesFuzzOpts = CombineFuzzOpts(
+		proxyTypeFuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(p *ProxyDefinition, c fuzz.Continue) {
+				c.Fuzz(&p.Delegate)
+				c.Fuzz(&p.ProxyType)
+				c.Fuzz(&p.Delay)
+			}),
+		},
+	)
+)
+
+func TestProxies_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[Proxies](t, 1000, proxiesFuzzOpts...)
+	AssertDecodeNilData[Proxies](t)
+	AssertEncodeEmptyObj[Proxies](t, 17)
+}
+
+func TestProxies_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{
+			Input: testProxies,
+			Expected: codec.MustHexDecodeString(
+				"0x04" +
+					"0100000000000000000000000000000000000000000000000000000000000000" +
+					"05" +
+					"0a000000" +
+					"64000000000000000000000000000000",
+			),
+		},
+	})
+}
+
+func TestAnnouncements_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[Announcements](t, 1000)
+	AssertDecodeNilData[Announcements](t)
+	AssertEncodeEmptyObj[Announcements](t, 17)
+}
+
+func TestNewEventProxyProxyRemoved(t *testing.T) {
+	event, err := NewEventProxyProxyRemoved(types.EventProxyProxyRemoved{
+		Phase:       types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 2},
+		Delegator:   types.AccountID{1},
+		Delegatee:   types.AccountID{2},
+		ProxyType:   types.U8(Borrow),
+		BlockNumber: 10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, EventProxyProxyRemoved{
+		Phase:     types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 2},
+		Delegator: types.AccountID{1},
+		Delegatee: types.AccountID{2},
+		ProxyType: Borrow,
+		Delay:     10,
+	}, event)
+}
@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// pureProxyPrefix is the module prefix used by the proxy pallet when deriving pure proxy accounts.
+var pureProxyPrefix = [16]byte{'m', 'o', 'd', 'l', 'p', 'y', '/', 'p', 'r', 'o', 'x', 'y', '_', '_', '_', '_'}
+
+// pureProxyEntropy is the tuple that is hashed by the proxy pallet in order to derive a pure proxy account.
+type pureProxyEntropy struct {
+	Prefix         [16]byte
+	Spawner        types.AccountID
+	Height         types.U32
+	ExtrinsicIndex types.U32
+	ProxyType      CentrifugeProxyType
+	Index          types.U16
+}
+
+// PureProxyAccount returns the account of the pure proxy created by the spawner with `Proxy.create_pure`
+// in the extrinsic at extrinsicIndex of the block at height.
+//
+// The index is the disambiguation index passed to `Proxy.create_pure`, which allows the spawner to create
+// multiple pure proxies of the same type in the same extrinsic.
+func PureProxyAccount(
+	spawner types.AccountID,
+	proxyType CentrifugeProxyType,
+	index types.U16,
+	height types.U32,
+	extrinsicIndex types.U32,
+) (types.AccountID, error) {
+	entropy, err := codec.Encode(pureProxyEntropy{
+		Prefix:         pureProxyPrefix,
+		Spawner:        spawner,
+		Height:         height,
+		ExtrinsicIndex: extrinsicIndex,
+		ProxyType:      proxyType,
+		Index:          index,
+	})
+
+	if err != nil {
+		return types.AccountID{}, err
+	}
+
+	hasher, err := hash.NewBlake2b256(nil)
+
+	if err != nil {
+		return types.AccountID{}, err
+	}
+
+	if _, err := hasher.Write(entropy); err != nil {
+		return types.AccountID{}, err
+	}
+
+	var account types.AccountID
+
+	copy(account[:], hasher.Sum(nil))
+
+	return account, nil
+}
@@ -0,0 +1,53 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPureProxyEntropy_Encode(t *testing.T) {
+	entropy := pureProxyEntropy{
+		Prefix:         pureProxyPrefix,
+		Spawner:        testSpawner,
+		Height:         100,
+		ExtrinsicIndex: 2,
+		ProxyType:      Borrow,
+		Index:          3,
+	}
+
+	b, err := codec.Encode(entropy)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"0x6d6f646c70792f70726f78795f5f5f5f"+
+			"0101010101010101010101010101010101010101010101010101010101010101"+
+			"6400000002000000050300",
+		codec.HexEncodeToString(b),
+	)
+}
+
+func TestPureProxyAccount(t *testing.T) {
+	account, err := PureProxyAccount(testSpawner, Borrow, 3, 100, 2)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"0x055c7bce467bdc76a5edfc5900a08c099bf2ce43711e46e4c48754ed514b03e1",
+		codec.HexEncodeToString(account[:]),
+	)
+
+	other, err := PureProxyAccount(testSpawner, Borrow, 4, 100, 2)
+	assert.NoError(t, err)
+	assert.NotEqual(t, account, other)
+
+	other, err = PureProxyAccount(testSpawner, Invest, 3, 100, 2)
+	assert.NoError(t, err)
+	assert.NotEqual(t, account, other)
+}
+
+var testSpawner = types.AccountID{
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+}
@@ -0,0 +1,423 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ProxyTypeVariant is a variant of the runtime's `ProxyType` enum.
+type ProxyTypeVariant struct {
+	Index uint8
+	Name  string
+}
+
+// ProxyTypeVariants returns the variants of the CentrifugeProxyType constants, sorted by index.
+func ProxyTypeVariants() []ProxyTypeVariant {
+	res := make([]ProxyTypeVariant, 0, len(ProxyTypeName))
+
+	for proxyType, name := range ProxyTypeName {
+		res = append(res, ProxyTypeVariant{Index: uint8(proxyType), Name: name})
+	}
+
+	sortVariants(res)
+
+	return res
+}
+
+var (
+	ErrProxyTypeNotFound = errors.New("proxy type not found in metadata")
+)
+
+const (
+	proxyTypeArgName = "proxy_type"
+)
+
+// LoadProxyTypeVariants returns the variants of the `ProxyType` enum of the runtime, sorted by index.
+//
+// The type is found through the `proxy_type` argument of `Proxy.add_proxy` in the V14 type registry.
+// Runtimes prefix deprecated variants with an underscore, for example `_Staking`, which is removed from the name.
+func LoadProxyTypeVariants(meta *types.Metadata) ([]ProxyTypeVariant, error) {
+	if meta.Version != 14 {
+		return nil, fmt.Errorf("%w: unsupported metadata version %d", ErrProxyTypeNotFound, meta.Version)
+	}
+
+	palletName, callName, _ := strings.Cut(AddProxyCallName, ".")
+
+	callType, err := palletCallType(meta.AsMetadataV14, palletName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, call := range callType.Def.Variant.Variants {
+		if string(call.Name) != callName {
+			continue
+		}
+
+		for _, field := range call.Fields {
+			if !field.HasName || string(field.Name) != proxyTypeArgName {
+				continue
+			}
+
+			proxyType, ok := meta.AsMetadataV14.EfficientLookup[field.Type.Int64()]
+
+			if !ok || !proxyType.Def.IsVariant {
+				return nil, fmt.Errorf("%w: %s is not an enum", ErrProxyTypeNotFound, proxyTypeArgName)
+			}
+
+			res := make([]ProxyTypeVariant, 0, len(proxyType.Def.Variant.Variants))
+
+			for _, variant := range proxyType.Def.Variant.Variants {
+				res = append(res, ProxyTypeVariant{
+					Index: uint8(variant.Index),
+					Name:  strings.TrimPrefix(string(variant.Name), "_"),
+				})
+			}
+
+			sortVariants(res)
+
+			return res, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s has no %s argument", ErrProxyTypeNotFound, AddProxyCallName, proxyTypeArgName)
+}
+
+func palletCallType(meta types.MetadataV14, palletName string) (*types.Si1Type, error) {
+	for _, pallet := range meta.Pallets {
+		if string(pallet.Name) != palletName || !pallet.HasCalls {
+			continue
+		}
+
+		callType, ok := meta.EfficientLookup[pallet.Calls.Type.Int64()]
+
+		if !ok {
+			return nil, fmt.Errorf("%w: missing call type of pallet %s", ErrProxyTypeNotFound, palletName)
+		}
+
+		return callType, nil
+	}
+
+	return nil, fmt.Errorf("%w: pallet %s not found", ErrProxyTypeNotFound, palletName)
+}
+
+// ReorderedVariant is a variant that exists on both sides of a comparison with different indices.
+type ReorderedVariant struct {
+	Name          string
+	ExpectedIndex uint8
+	ActualIndex   uint8
+}
+
+// ProxyTypeDiff holds the differences between two lists of proxy type variants.
+type ProxyTypeDiff struct {
+	// Added holds the variants that are only present in the actual list.
+	Added []ProxyTypeVariant
+	// Removed holds the variants that are only present in the expected list.
+	Removed []ProxyTypeVariant
+	// Reordered holds the variants whose index changed.
+	Reordered []ReorderedVariant
+}
+
+// IsEmpty returns true if both lists hold the same variants at the same indices.
+func (d ProxyTypeDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Reordered) == 0
+}
+
+func (d ProxyTypeDiff) String() string {
+	if d.IsEmpty() {
+		return "no changes"
+	}
+
+	var parts []string
+
+	for _, variant := range d.Added {
+		parts = append(parts, fmt.Sprintf("added %s at %d", variant.Name, variant.Index))
+	}
+
+	for _, variant := range d.Removed {
+		parts = append(parts, fmt.Sprintf("removed %s at %d", variant.Name, variant.Index))
+	}
+
+	for _, variant := range d.Reordered {
+		parts = append(
+			parts,
+			fmt.Sprintf("moved %s from %d to %d", variant.Name, variant.ExpectedIndex, variant.ActualIndex),
+		)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// CompareProxyTypeVariants returns the differences between the expected and the actual variants, matched by name.
+func CompareProxyTypeVariants(expected, actual []ProxyTypeVariant) ProxyTypeDiff {
+	var diff ProxyTypeDiff
+
+	actualIndices := make(map[string]uint8, len(actual))
+
+	for _, variant := range actual {
+		actualIndices[variant.Name] = variant.Index
+	}
+
+	expectedNames := make(map[string]struct{}, len(expected))
+
+	for _, variant := range sortedVariants(expected) {
+		expectedNames[variant.Name] = struct{}{}
+
+		actualIndex, ok := actualIndices[variant.Name]
+
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, variant)
+		case actualIndex != variant.Index:
+			diff.Reordered = append(diff.Reordered, ReorderedVariant{
+				Name:          variant.Name,
+				ExpectedIndex: variant.Index,
+				ActualIndex:   actualIndex,
+			})
+		}
+	}
+
+	for _, variant := range sortedVariants(actual) {
+		if _, ok := expectedNames[variant.Name]; !ok {
+			diff.Added = append(diff.Added, variant)
+		}
+	}
+
+	return diff
+}
+
+// CheckProxyTypes compares the CentrifugeProxyType constants with the `ProxyType` enum of the runtime.
+func CheckProxyTypes(meta *types.Metadata) (ProxyTypeDiff, error) {
+	actual, err := LoadProxyTypeVariants(meta)
+
+	if err != nil {
+		return ProxyTypeDiff{}, err
+	}
+
+	return CompareProxyTypeVariants(ProxyTypeVariants(), actual), nil
+}
+
+// Runtime is the spec name of a Centrifuge runtime.
+type Runtime string
+
+const (
+	CentrifugeRuntime  Runtime = "centrifuge"
+	AltairRuntime      Runtime = "altair"
+	DevelopmentRuntime Runtime = "centrifuge-devel"
+)
+
+var (
+	ErrUnknownRuntime          = errors.New("unknown runtime")
+	ErrUnknownProxyTypeVariant = errors.New("unknown proxy type variant")
+)
+
+// ProxyTypeTable is the list of proxy type variants of a runtime, starting at a spec version.
+type ProxyTypeTable struct {
+	Runtime     Runtime
+	SpecVersion types.U32
+	Variants    []ProxyTypeVariant
+}
+
+// LoadProxyTypeTable returns the table of the runtime version, with the variants found in its metadata.
+func LoadProxyTypeTable(meta *types.Metadata, version types.RuntimeVersion) (ProxyTypeTable, error) {
+	variants, err := LoadProxyTypeVariants(meta)
+
+	if err != nil {
+		return ProxyTypeTable{}, err
+	}
+
+	return ProxyTypeTable{
+		Runtime:     Runtime(version.SpecName),
+		SpecVersion: version.SpecVersion,
+		Variants:    variants,
+	}, nil
+}
+
+// ProxyType returns the CentrifugeProxyType of the variant at the index of the runtime, matched by name.
+func (t ProxyTypeTable) ProxyType(index uint8) (CentrifugeProxyType, error) {
+	for _, variant := range t.Variants {
+		if variant.Index != index {
+			continue
+		}
+
+		proxyType, ok := ProxyTypeValue[variant.Name]
+
+		if !ok {
+			return 0, fmt.Errorf("%w: %s", ErrUnknownProxyTypeVariant, variant.Name)
+		}
+
+		return proxyType, nil
+	}
+
+	return 0, fmt.Errorf("%w: index %d of %s %d", ErrUnknownProxyTypeVariant, index, t.Runtime, t.SpecVersion)
+}
+
+// Index returns the index of the CentrifugeProxyType in the runtime, matched by name.
+func (t ProxyTypeTable) Index(proxyType CentrifugeProxyType) (uint8, error) {
+	name := ProxyTypeName[proxyType]
+
+	for _, variant := range t.Variants {
+		if variant.Name == name {
+			return variant.Index, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %d in %s %d", ErrUnknownProxyTypeVariant, proxyType, t.Runtime, t.SpecVersion)
+}
+
+// ProxyTypeTables holds the proxy type tables of several runtimes, for example the ones loaded for each
+// runtime upgrade of a chain.
+type ProxyTypeTables struct {
+	mu     sync.RWMutex
+	tables map[Runtime][]ProxyTypeTable
+}
+
+func NewProxyTypeTables(tables ...ProxyTypeTable) *ProxyTypeTables {
+	res := &ProxyTypeTables{
+		tables: make(map[Runtime][]ProxyTypeTable),
+	}
+
+	for _, table := range tables {
+		res.Add(table)
+	}
+
+	return res
+}
+
+// Add adds the table, replacing the table of the same runtime and spec version.
+func (t *ProxyTypeTables) Add(table ProxyTypeTable) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tables := t.tables[table.Runtime]
+
+	i := sort.Search(len(tables), func(i int) bool {
+		return tables[i].SpecVersion >= table.SpecVersion
+	})
+
+	if i < len(tables) && tables[i].SpecVersion == table.SpecVersion {
+		tables[i] = table
+
+		return
+	}
+
+	tables = append(tables, ProxyTypeTable{})
+	copy(tables[i+1:], tables[i:])
+	tables[i] = table
+
+	t.tables[table.Runtime] = tables
+}
+
+// TableFor returns the table that applies to the spec version of the runtime, which is the one with the highest
+// spec version that is not above it.
+func (t *ProxyTypeTables) TableFor(runtime Runtime, specVersion types.U32) (ProxyTypeTable, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	tables := t.tables[runtime]
+
+	i := sort.Search(len(tables), func(i int) bool {
+		return tables[i].SpecVersion > specVersion
+	})
+
+	if i == 0 {
+		return ProxyTypeTable{}, fmt.Errorf("%w: %s at spec version %d", ErrUnknownRuntime, runtime, specVersion)
+	}
+
+	return tables[i-1], nil
+}
+
+// Check compares the table that applies to the runtime version with the `ProxyType` enum of the runtime metadata.
+func (t *ProxyTypeTables) Check(meta *types.Metadata, version types.RuntimeVersion) (ProxyTypeDiff, error) {
+	table, err := t.TableFor(Runtime(version.SpecName), version.SpecVersion)
+
+	if err != nil {
+		return ProxyTypeDiff{}, err
+	}
+
+	actual, err := LoadProxyTypeVariants(meta)
+
+	if err != nil {
+		return ProxyTypeDiff{}, err
+	}
+
+	return CompareProxyTypeVariants(table.Variants, actual), nil
+}
+
+// Use selects the table that applies to the runtime version for the encoding of CentrifugeProxyType,
+// see UseProxyTypeTable.
+func (t *ProxyTypeTables) Use(version types.RuntimeVersion) error {
+	table, err := t.TableFor(Runtime(version.SpecName), version.SpecVersion)
+
+	if err != nil {
+		return err
+	}
+
+	UseProxyTypeTable(&table)
+
+	return nil
+}
+
+var (
+	activeTableMu sync.RWMutex
+	activeTable   *ProxyTypeTable
+)
+
+// UseProxyTypeTable sets the table of the connected runtime, which maps CentrifugeProxyType to the variant indices
+// of the runtime when it's encoded or decoded. The constants are used as indices if no table is set,
+// which is the default, or if the table is nil.
+func UseProxyTypeTable(table *ProxyTypeTable) {
+	activeTableMu.Lock()
+	defer activeTableMu.Unlock()
+
+	activeTable = table
+}
+
+func proxyTypeFromIndex(index uint8) (CentrifugeProxyType, error) {
+	activeTableMu.RLock()
+	defer activeTableMu.RUnlock()
+
+	if activeTable != nil {
+		return activeTable.ProxyType(index)
+	}
+
+	proxyType := CentrifugeProxyType(index)
+
+	if _, ok := proxyTypeMap[proxyType]; !ok {
+		return 0, fmt.Errorf("%w: index %d", ErrUnknownProxyTypeVariant, index)
+	}
+
+	return proxyType, nil
+}
+
+func proxyTypeIndex(proxyType CentrifugeProxyType) (uint8, error) {
+	activeTableMu.RLock()
+	defer activeTableMu.RUnlock()
+
+	if activeTable != nil {
+		return activeTable.Index(proxyType)
+	}
+
+	return uint8(proxyType), nil
+}
+
+func sortedVariants(variants []ProxyTypeVariant) []ProxyTypeVariant {
+	res := make([]ProxyTypeVariant, len(variants))
+
+	copy(res, variants)
+
+	sortVariants(res)
+
+	return res
+}
+
+func sortVariants(variants []ProxyTypeVariant) {
+	sort.Slice(variants, func(i, j int) bool {
+		return variants[i].Index < variants[j].Index
+	})
+}
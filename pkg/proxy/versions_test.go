@@ -0,0 +1,185 @@
+package proxy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/internal/testmeta"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+// newProxyTypeMeta returns metadata in which the `proxy_type` argument of `Proxy.add_proxy`
+// is an enum with the provided variants.
+func newProxyTypeMeta(variants ...string) *types.Metadata {
+	meta := testmeta.NewMetadata(testmeta.Pallet{Name: "Proxy", Index: 93, Calls: []string{"proxy", "add_proxy"}})
+
+	const proxyTypeID = 100
+
+	proxyType := &types.Si1Type{
+		Def: types.Si1TypeDef{IsVariant: true},
+	}
+
+	for i, variant := range variants {
+		proxyType.Def.Variant.Variants = append(proxyType.Def.Variant.Variants, types.Si1Variant{
+			Name:  types.Text(variant),
+			Index: types.U8(i),
+		})
+	}
+
+	meta.AsMetadataV14.EfficientLookup[proxyTypeID] = proxyType
+
+	calls := meta.AsMetadataV14.EfficientLookup[meta.AsMetadataV14.Pallets[0].Calls.Type.Int64()]
+
+	calls.Def.Variant.Variants[1].Fields = []types.Si1Field{
+		{HasName: true, Name: "delegate"},
+		{HasName: true, Name: "proxy_type", Type: types.NewSi1LookupTypeIDFromUInt(proxyTypeID)},
+		{HasName: true, Name: "delay"},
+	}
+
+	return meta
+}
+
+var testRuntimeProxyTypes = []string{
+	"Any",
+	"NonTransfer",
+	"Governance",
+	"_Staking",
+	"NonProxy",
+	"Borrow",
+	"Invest",
+	"ProxyManagement",
+	"KeystoreManagement",
+	"PodOperation",
+	"PodAuth",
+	"PermissionManagement",
+}
+
+func TestProxyTypeConstants(t *testing.T) {
+	assert.Len(t, ProxyTypeName, len(proxyTypeMap))
+	assert.Len(t, ProxyTypeValue, len(proxyTypeMap))
+
+	for proxyType := range proxyTypeMap {
+		assert.Equal(t, proxyType, ProxyTypeValue[ProxyTypeName[proxyType]])
+	}
+}
+
+func TestLoadProxyTypeVariants(t *testing.T) {
+	variants, err := LoadProxyTypeVariants(newProxyTypeMeta(testRuntimeProxyTypes...))
+	assert.NoError(t, err)
+	assert.Equal(t, ProxyTypeVariants(), variants)
+
+	_, err = LoadProxyTypeVariants(testmeta.NewMetadata(testmeta.Pallet{Name: "Proxy", Calls: []string{"add_proxy"}}))
+	assert.True(t, errors.Is(err, ErrProxyTypeNotFound))
+
+	_, err = LoadProxyTypeVariants(testmeta.NewMetadata())
+	assert.True(t, errors.Is(err, ErrProxyTypeNotFound))
+}
+
+func TestCheckProxyTypes(t *testing.T) {
+	diff, err := CheckProxyTypes(newProxyTypeMeta(testRuntimeProxyTypes...))
+	assert.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+	assert.Equal(t, "no changes", diff.String())
+
+	// Borrow and Invest are swapped, PodAuth is removed and a new variant is appended.
+	diff, err = CheckProxyTypes(newProxyTypeMeta(
+		"Any",
+		"NonTransfer",
+		"Governance",
+		"_Staking",
+		"NonProxy",
+		"Invest",
+		"Borrow",
+		"ProxyManagement",
+		"KeystoreManagement",
+		"PodOperation",
+		"PermissionManagement",
+		"Transfer",
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, ProxyTypeDiff{
+		Added:   []ProxyTypeVariant{{Index: 11, Name: "Transfer"}},
+		Removed: []ProxyTypeVariant{{Index: 10, Name: "PodAuth"}},
+		Reordered: []ReorderedVariant{
+			{Name: "Borrow", ExpectedIndex: 5, ActualIndex: 6},
+			{Name: "Invest", ExpectedIndex: 6, ActualIndex: 5},
+			{Name: "PermissionManagement", ExpectedIndex: 11, ActualIndex: 10},
+		},
+	}, diff)
+	assert.Equal(
+		t,
+		"added Transfer at 11, removed PodAuth at 10, moved Borrow from 5 to 6, moved Invest from 6 to 5, "+
+			"moved PermissionManagement from 11 to 10",
+		diff.String(),
+	)
+}
+
+func TestProxyTypeTables(t *testing.T) {
+	defer UseProxyTypeTable(nil)
+
+	// The second table swaps Borrow and Invest, like a runtime upgrade that reorders variants.
+	reordered := append([]string(nil), testRuntimeProxyTypes...)
+	reordered[Borrow], reordered[Invest] = reordered[Invest], reordered[Borrow]
+
+	before, err := LoadProxyTypeTable(
+		newProxyTypeMeta(testRuntimeProxyTypes...),
+		types.RuntimeVersion{SpecName: string(AltairRuntime), SpecVersion: 1000},
+	)
+	assert.NoError(t, err)
+
+	afterMeta := newProxyTypeMeta(reordered...)
+	afterVersion := types.RuntimeVersion{SpecName: string(AltairRuntime), SpecVersion: 1020}
+
+	after, err := LoadProxyTypeTable(afterMeta, afterVersion)
+	assert.NoError(t, err)
+
+	tables := NewProxyTypeTables(after, before)
+
+	table, err := tables.TableFor(AltairRuntime, 1019)
+	assert.NoError(t, err)
+	assert.Equal(t, before, table)
+
+	table, err = tables.TableFor(AltairRuntime, 1100)
+	assert.NoError(t, err)
+	assert.Equal(t, after, table)
+
+	_, err = tables.TableFor(AltairRuntime, 999)
+	assert.True(t, errors.Is(err, ErrUnknownRuntime))
+
+	_, err = tables.TableFor(CentrifugeRuntime, 1020)
+	assert.True(t, errors.Is(err, ErrUnknownRuntime))
+
+	diff, err := tables.Check(afterMeta, afterVersion)
+	assert.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+
+	diff, err = tables.Check(afterMeta, types.RuntimeVersion{SpecName: string(AltairRuntime), SpecVersion: 1019})
+	assert.NoError(t, err)
+	assert.Len(t, diff.Reordered, 2)
+
+	// The indices of the connected runtime are used when encoding and decoding.
+	assert.NoError(t, tables.Use(afterVersion))
+
+	encoded, err := codec.Encode(Borrow)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{byte(Invest)}, encoded)
+
+	var proxyType CentrifugeProxyType
+
+	assert.NoError(t, codec.Decode([]byte{byte(Invest)}, &proxyType))
+	assert.Equal(t, Borrow, proxyType)
+
+	event, err := NewEventProxyProxyAdded(types.EventProxyProxyAdded{ProxyType: types.U8(Borrow)})
+	assert.NoError(t, err)
+	assert.Equal(t, Invest, event.ProxyType)
+
+	assert.True(t, errors.Is(codec.Decode([]byte{12}, &proxyType), ErrUnknownProxyTypeVariant))
+
+	UseProxyTypeTable(nil)
+
+	encoded, err = codec.Encode(Borrow)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{byte(Borrow)}, encoded)
+}
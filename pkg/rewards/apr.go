@@ -0,0 +1,89 @@
+package rewards
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	SecondsPerYear      types.U64 = 365 * 24 * 60 * 60
+	MillisecondsPerYear           = SecondsPerYear * 1000
+)
+
+var (
+	ErrNoStake       = errors.New("group has no stake")
+	ErrZeroDuration  = errors.New("epoch duration is zero")
+	ErrInvalidAmount = errors.New("invalid amount")
+)
+
+// APREstimator estimates the annualized reward rate of staking a currency, from the current stake
+// of the `LiquidityRewardsBase` pallet and the active epoch configuration of the `LiquidityRewards` pallet.
+type APREstimator struct {
+	epochs         *EpochTracker
+	stakes         *Simulator
+	momentsPerYear types.U64
+}
+
+// NewAPREstimator returns an estimator that uses the provided number of moments per year, in the unit
+// of the epoch durations, for example SecondsPerYear.
+func NewAPREstimator(epochs *EpochTracker, stakes *Simulator, momentsPerYear types.U64) *APREstimator {
+	return &APREstimator{
+		epochs:         epochs,
+		stakes:         stakes,
+		momentsPerYear: momentsPerYear,
+	}
+}
+
+// Estimate returns the yearly reward per staked unit of the currency, assuming that the active epoch configuration
+// and the stake of the group, increased by additionalStake, remain unchanged for a year. Rewards are not compounded.
+//
+// The rate is expressed in the smallest units of both the reward and the staked currency,
+// so it must be adjusted by their decimals and prices to obtain a percentage.
+func (e *APREstimator) Estimate(currencyID currency.CurrencyID, additionalStake types.U128) (float64, error) {
+	groupID, ok := e.stakes.CurrencyGroup(currencyID)
+
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrCurrencyNotAttached, currencyID)
+	}
+
+	active := e.epochs.Active()
+
+	if active.Duration == 0 {
+		return 0, ErrZeroDuration
+	}
+
+	stake := u128Int(e.stakes.GroupStake(groupID))
+
+	if additionalStake.Int != nil {
+		if additionalStake.Sign() < 0 {
+			return 0, fmt.Errorf("%w: negative stake", ErrInvalidAmount)
+		}
+
+		stake.Add(stake, additionalStake.Int)
+	}
+
+	if stake.Sign() == 0 {
+		return 0, fmt.Errorf("%w: %d", ErrNoStake, groupID)
+	}
+
+	epochReward := new(big.Int)
+
+	for _, groupReward := range active.GroupRewards() {
+		if groupReward.GroupID == groupID {
+			epochReward = u128Int(groupReward.Amount)
+		}
+	}
+
+	yearlyReward := new(big.Rat).SetFrac(
+		new(big.Int).Mul(epochReward, new(big.Int).SetUint64(uint64(e.momentsPerYear))),
+		new(big.Int).SetUint64(uint64(active.Duration)),
+	)
+
+	rate, _ := new(big.Rat).Quo(yearlyReward, new(big.Rat).SetInt(stake)).Float64()
+
+	return rate, nil
+}
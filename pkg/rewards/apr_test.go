@@ -0,0 +1,54 @@
+package rewards
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPREstimator_Estimate(t *testing.T) {
+	stakes := NewSimulator()
+
+	stakes.AttachCurrency(testCurrencyA, 1)
+	stakes.AttachCurrency(testCurrencyB, 2)
+
+	assert.NoError(t, stakes.DepositStake(testAlice, testCurrencyA, u128(10_000)))
+
+	// A daily epoch that distributes 75 to group 1 and 225 to group 2.
+	epochs := NewEpochTracker(EpochData{
+		Duration: 24 * 60 * 60,
+		Reward:   u128(300),
+		Weights: []EpochWeights{
+			{GroupID: 1, Weight: 1},
+			{GroupID: 2, Weight: 3},
+		},
+	}, 0)
+
+	estimator := NewAPREstimator(epochs, stakes, SecondsPerYear)
+
+	rate, err := estimator.Estimate(testCurrencyA, types.U128{})
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.7375, rate, 1e-9)
+
+	// Additional stake dilutes the rewards.
+	rate, err = estimator.Estimate(testCurrencyA, u128(10_000))
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.36875, rate, 1e-9)
+
+	_, err = estimator.Estimate(testCurrencyB, u128(0))
+	assert.ErrorIs(t, err, ErrNoStake)
+
+	rate, err = estimator.Estimate(testCurrencyB, u128(82_125))
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, rate, 1e-9)
+
+	_, err = estimator.Estimate(testCurrencyB, u128(-1))
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+
+	_, err = NewAPREstimator(epochs, NewSimulator(), SecondsPerYear).Estimate(testCurrencyA, u128(1))
+	assert.ErrorIs(t, err, ErrCurrencyNotAttached)
+
+	_, err = NewAPREstimator(NewEpochTracker(EpochData{}, 0), stakes, SecondsPerYear).Estimate(testCurrencyA, u128(1))
+	assert.ErrorIs(t, err, ErrZeroDuration)
+}
@@ -0,0 +1,83 @@
+package rewards
+
+import (
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	StakeCallName                = "LiquidityRewards.stake"
+	UnstakeCallName              = "LiquidityRewards.unstake"
+	ClaimRewardCallName          = "LiquidityRewards.claim_reward"
+	SetDistributedRewardCallName = "LiquidityRewards.set_distributed_reward"
+	SetEpochDurationCallName     = "LiquidityRewards.set_epoch_duration"
+	SetGroupWeightCallName       = "LiquidityRewards.set_group_weight"
+	SetCurrencyGroupCallName     = "LiquidityRewards.set_currency_group"
+)
+
+// The calls that take a currency accept the currency ID of GSRPC, which is converted with currency.FromGSRPC.
+
+// NewStakeCall returns the call that deposits a stake of the currency for the caller.
+func NewStakeCall(meta *types.Metadata, currencyID types.CurrencyID, amount types.U128) (types.Call, error) {
+	runtimeCurrencyID, err := currency.FromGSRPC(currencyID)
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, StakeCallName, runtimeCurrencyID, amount)
+}
+
+// NewUnstakeCall returns the call that withdraws a stake of the currency for the caller.
+func NewUnstakeCall(meta *types.Metadata, currencyID types.CurrencyID, amount types.U128) (types.Call, error) {
+	runtimeCurrencyID, err := currency.FromGSRPC(currencyID)
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, UnstakeCallName, runtimeCurrencyID, amount)
+}
+
+// NewClaimRewardCall returns the call that claims the reward of the caller for staking the currency.
+func NewClaimRewardCall(meta *types.Metadata, currencyID types.CurrencyID) (types.Call, error) {
+	runtimeCurrencyID, err := currency.FromGSRPC(currencyID)
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, ClaimRewardCallName, runtimeCurrencyID)
+}
+
+// The following calls require the admin origin, their changes are applied when the active epoch ends.
+
+// NewSetDistributedRewardCall returns the call that sets the reward distributed in each epoch.
+func NewSetDistributedRewardCall(meta *types.Metadata, balance types.U128) (types.Call, error) {
+	return types.NewCall(meta, SetDistributedRewardCallName, balance)
+}
+
+// NewSetEpochDurationCall returns the call that sets the duration of the epochs.
+func NewSetEpochDurationCall(meta *types.Metadata, duration types.U64) (types.Call, error) {
+	return types.NewCall(meta, SetEpochDurationCallName, duration)
+}
+
+// NewSetGroupWeightCall returns the call that sets the weight of a group in the reward distribution.
+func NewSetGroupWeightCall(meta *types.Metadata, groupID types.U32, weight types.U64) (types.Call, error) {
+	return types.NewCall(meta, SetGroupWeightCallName, groupID, weight)
+}
+
+// NewSetCurrencyGroupCall returns the call that attaches the currency to a group.
+func NewSetCurrencyGroupCall(
+	meta *types.Metadata,
+	currencyID types.CurrencyID,
+	groupID types.U32,
+) (types.Call, error) {
+	runtimeCurrencyID, err := currency.FromGSRPC(currencyID)
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.NewCall(meta, SetCurrencyGroupCallName, runtimeCurrencyID, groupID)
+}
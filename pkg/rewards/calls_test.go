@@ -0,0 +1,70 @@
+package rewards
+
+import (
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStakeCall(t *testing.T) {
+	call, err := NewStakeCall(testMeta, testGSRPCCurrencyA, u128(100))
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 111, MethodIndex: 0}, call.CallIndex)
+	assert.Equal(
+		t,
+		types.Args(codec.MustHexDecodeString("0x"+testCurrencyAEncoded+"64000000000000000000000000000000")),
+		call.Args,
+	)
+}
+
+func TestNewUnstakeCall(t *testing.T) {
+	call, err := NewUnstakeCall(testMeta, testGSRPCCurrencyA, u128(100))
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 111, MethodIndex: 1}, call.CallIndex)
+	assert.Equal(
+		t,
+		types.Args(codec.MustHexDecodeString("0x"+testCurrencyAEncoded+"64000000000000000000000000000000")),
+		call.Args,
+	)
+}
+
+func TestNewClaimRewardCall(t *testing.T) {
+	call, err := NewClaimRewardCall(testMeta, testGSRPCCurrencyA)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 111, MethodIndex: 2}, call.CallIndex)
+	assert.Equal(t, types.Args(codec.MustHexDecodeString("0x"+testCurrencyAEncoded)), call.Args)
+
+	_, err = NewClaimRewardCall(testMeta, types.CurrencyID{})
+	assert.ErrorIs(t, err, currency.ErrUnsupportedVariant)
+}
+
+func TestNewSetDistributedRewardCall(t *testing.T) {
+	call, err := NewSetDistributedRewardCall(testMeta, u128(100))
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 111, MethodIndex: 3}, call.CallIndex)
+	assert.Equal(t, types.Args(codec.MustHexDecodeString("0x64000000000000000000000000000000")), call.Args)
+}
+
+func TestNewSetEpochDurationCall(t *testing.T) {
+	call, err := NewSetEpochDurationCall(testMeta, 86400)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 111, MethodIndex: 4}, call.CallIndex)
+	assert.Equal(t, types.Args(codec.MustHexDecodeString("0x8051010000000000")), call.Args)
+}
+
+func TestNewSetGroupWeightCall(t *testing.T) {
+	call, err := NewSetGroupWeightCall(testMeta, 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 111, MethodIndex: 5}, call.CallIndex)
+	assert.Equal(t, types.Args(codec.MustHexDecodeString("0x010000000300000000000000")), call.Args)
+}
+
+func TestNewSetCurrencyGroupCall(t *testing.T) {
+	call, err := NewSetCurrencyGroupCall(testMeta, testGSRPCCurrencyA, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CallIndex{SectionIndex: 111, MethodIndex: 6}, call.CallIndex)
+	assert.Equal(t, types.Args(codec.MustHexDecodeString("0x"+testCurrencyAEncoded+"02000000")), call.Args)
+}
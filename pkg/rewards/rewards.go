@@ -1,6 +1,9 @@
 package rewards
 
-import "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+import (
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
 
 // These are the events for the `liquidity-rewards` pallet.
 
@@ -17,7 +20,7 @@ type EpochWeights struct {
 }
 
 type EpochCurrencies struct {
-	CurrencyID types.CurrencyID
+	CurrencyID currency.CurrencyID
 	GroupID    types.U32
 }
 
@@ -1,6 +1,9 @@
 package rewards
 
-import "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+import (
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
 
 // These are the events for the `rewards` pallet.
 
@@ -14,7 +17,7 @@ type EventLiquidityRewardsBaseGroupRewarded struct {
 type EventLiquidityRewardsBaseStakeDeposited struct {
 	Phase      types.Phase
 	GroupID    types.U32
-	CurrencyID types.CurrencyID
+	CurrencyID currency.CurrencyID
 	AccountID  types.AccountID
 	Amount     types.U128
 	Topics     []types.Hash
@@ -23,7 +26,7 @@ type EventLiquidityRewardsBaseStakeDeposited struct {
 type EventLiquidityRewardsBaseStakeWithdrawn struct {
 	Phase      types.Phase
 	GroupID    types.U32
-	CurrencyID types.CurrencyID
+	CurrencyID currency.CurrencyID
 	AccountID  types.AccountID
 	Amount     types.U128
 	Topics     []types.Hash
@@ -32,7 +35,7 @@ type EventLiquidityRewardsBaseStakeWithdrawn struct {
 type EventLiquidityRewardsBaseRewardClaimed struct {
 	Phase      types.Phase
 	GroupID    types.U32
-	CurrencyID types.CurrencyID
+	CurrencyID currency.CurrencyID
 	AccountID  types.AccountID
 	Amount     types.U128
 	Topics     []types.Hash
@@ -40,7 +43,7 @@ type EventLiquidityRewardsBaseRewardClaimed struct {
 
 type EventLiquidityRewardsBaseCurrencyAttached struct {
 	Phase      types.Phase
-	CurrencyID types.CurrencyID
+	CurrencyID currency.CurrencyID
 	From       types.Option[types.U32]
 	To         types.U32
 	Topics     []types.Hash
@@ -0,0 +1,57 @@
+package rewards
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/chain-custom-types/pkg/internal/testmeta"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	testMeta = testmeta.NewMetadata(
+		testmeta.Pallet{
+			Name:  "LiquidityRewardsBase",
+			Index: 110,
+			Storage: []testmeta.Storage{
+				{Name: "Groups", Keys: 1},
+				{Name: "Currencies", Keys: 1},
+				{Name: "StakeAccounts", Keys: 2},
+			},
+		},
+		testmeta.Pallet{
+			Name:  "LiquidityRewards",
+			Index: 111,
+			Calls: []string{
+				"stake",
+				"unstake",
+				"claim_reward",
+				"set_distributed_reward",
+				"set_epoch_duration",
+				"set_group_weight",
+				"set_currency_group",
+			},
+			Storage: []testmeta.Storage{
+				{Name: "EndOfEpoch"},
+				{Name: "ActiveEpochData"},
+				{Name: "NextEpochChanges"},
+			},
+		},
+	)
+
+	testGSRPCCurrencyA = types.CurrencyID{IsForeignAsset: true, AsForeignAsset: 1}
+	testGSRPCCurrencyB = types.CurrencyID{IsForeignAsset: true, AsForeignAsset: 2}
+
+	// testCurrencyAEncoded is the encoded testGSRPCCurrencyA, `ForeignAsset(1)`.
+	testCurrencyAEncoded = "0401000000"
+
+	testCurrencyA = currency.NewForeignAssetCurrencyID(1)
+	testCurrencyB = currency.NewForeignAssetCurrencyID(2)
+
+	testAlice = types.AccountID{1}
+	testBob   = types.AccountID{2}
+)
+
+func u128(v int64) types.U128 {
+	return types.NewU128(*big.NewInt(v))
+}
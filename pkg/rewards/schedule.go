@@ -0,0 +1,177 @@
+package rewards
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// EpochData is the configuration of the active epoch of the `liquidity-rewards` pallet.
+// Weights are sorted by group ID.
+type EpochData struct {
+	Duration types.U64
+	Reward   types.U128
+	Weights  []EpochWeights
+}
+
+// Apply returns the configuration that results from applying the changes, like the runtime does when an epoch ends.
+func (e EpochData) Apply(changes EpochChanges) EpochData {
+	res := EpochData{
+		Duration: e.Duration,
+		Reward:   e.Reward,
+		Weights:  make([]EpochWeights, len(e.Weights)),
+	}
+
+	copy(res.Weights, e.Weights)
+
+	if ok, duration := changes.Duration.Unwrap(); ok {
+		res.Duration = duration
+	}
+
+	if ok, reward := changes.Reward.Unwrap(); ok {
+		res.Reward = reward
+	}
+
+	for _, change := range changes.Weights {
+		i := sort.Search(len(res.Weights), func(i int) bool {
+			return res.Weights[i].GroupID >= change.GroupID
+		})
+
+		if i < len(res.Weights) && res.Weights[i].GroupID == change.GroupID {
+			res.Weights[i].Weight = change.Weight
+
+			continue
+		}
+
+		res.Weights = append(res.Weights, EpochWeights{})
+		copy(res.Weights[i+1:], res.Weights[i:])
+		res.Weights[i] = change
+	}
+
+	return res
+}
+
+// GroupReward is the reward that a group receives when an epoch ends.
+type GroupReward struct {
+	GroupID types.U32
+	Amount  types.U128
+}
+
+// GroupRewards returns the rewards that each group receives when the epoch ends. The reward is split by weight,
+// rounding down both the rate of each group and its reward, like `distribute_reward_with_weights` of the
+// `rewards` pallet.
+func (e EpochData) GroupRewards() []GroupReward {
+	totalWeight := new(big.Int)
+
+	for _, weight := range e.Weights {
+		totalWeight.Add(totalWeight, new(big.Int).SetUint64(uint64(weight.Weight)))
+	}
+
+	res := make([]GroupReward, 0, len(e.Weights))
+
+	for _, weight := range e.Weights {
+		amount := new(big.Int)
+
+		if totalWeight.Sign() > 0 {
+			rate := rateFromRational(new(big.Int).SetUint64(uint64(weight.Weight)), totalWeight)
+			amount = mulInt(rate, u128Int(e.Reward))
+		}
+
+		res = append(res, GroupReward{
+			GroupID: weight.GroupID,
+			Amount:  types.NewU128(*amount),
+		})
+	}
+
+	return res
+}
+
+// Distribution is the reward distribution that happens when an epoch ends.
+type Distribution struct {
+	// At is the moment at which the epoch ends. The runtime distributes the reward in the first block
+	// whose timestamp reaches it, and the next epoch ends after its duration counted from that block.
+	At           types.U64
+	Reward       types.U128
+	GroupRewards []GroupReward
+}
+
+// EpochTracker keeps the epoch configuration of the `liquidity-rewards` pallet, built from its `NewEpoch` events.
+type EpochTracker struct {
+	active     EpochData
+	endOfEpoch types.U64
+	currencies map[currency.CurrencyID]types.U32
+}
+
+// NewEpochTracker returns a tracker starting from the active epoch configuration and the moment at which it ends,
+// as stored in `ActiveEpochData` and `EndOfEpoch`.
+func NewEpochTracker(active EpochData, endOfEpoch types.U64) *EpochTracker {
+	return &EpochTracker{
+		active:     active.Apply(EpochChanges{}),
+		endOfEpoch: endOfEpoch,
+		currencies: make(map[currency.CurrencyID]types.U32),
+	}
+}
+
+// Active returns the configuration of the active epoch.
+func (t *EpochTracker) Active() EpochData {
+	return t.active.Apply(EpochChanges{})
+}
+
+// EndOfEpoch returns the moment at which the active epoch ends.
+func (t *EpochTracker) EndOfEpoch() types.U64 {
+	return t.endOfEpoch
+}
+
+// CurrencyGroup returns the group to which the currency was attached by the epoch changes.
+func (t *EpochTracker) CurrencyGroup(currencyID currency.CurrencyID) (types.U32, bool) {
+	groupID, ok := t.currencies[currencyID]
+
+	return groupID, ok
+}
+
+// ProcessNewEpoch applies a `NewEpoch` event and returns the distribution of the epoch that ended.
+func (t *EpochTracker) ProcessNewEpoch(event EventLiquidityRewardsNewEpoch) Distribution {
+	distribution := Distribution{
+		At:           t.endOfEpoch,
+		Reward:       t.active.Reward,
+		GroupRewards: t.active.GroupRewards(),
+	}
+
+	t.active = t.active.Apply(event.LastChanges)
+	// The event holds the reward of the new epoch, which matches the applied changes.
+	t.active.Reward = event.Reward
+	t.endOfEpoch = event.EndsOn
+
+	for _, change := range event.LastChanges.Currencies {
+		t.currencies[change.CurrencyID] = change.GroupID
+	}
+
+	return distribution
+}
+
+// Project returns the next distributions, given the changes that are pending for the next epoch,
+// as stored in `NextEpochChanges`. The moments assume that each epoch ends exactly on time.
+func (t *EpochTracker) Project(pending EpochChanges, count int) []Distribution {
+	res := make([]Distribution, 0, count)
+
+	data := t.active
+	at := t.endOfEpoch
+
+	for i := 0; i < count; i++ {
+		res = append(res, Distribution{
+			At:           at,
+			Reward:       data.Reward,
+			GroupRewards: data.GroupRewards(),
+		})
+
+		if i == 0 {
+			data = data.Apply(pending)
+		}
+
+		at += data.Duration
+	}
+
+	return res
+}
@@ -0,0 +1,105 @@
+package rewards
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testEpochData = EpochData{
+		Duration: 100,
+		Reward:   u128(1000),
+		Weights: []EpochWeights{
+			{GroupID: 1, Weight: 1},
+			{GroupID: 2, Weight: 2},
+		},
+	}
+)
+
+func assertGroupRewards(t *testing.T, expected map[types.U32]int64, actual []GroupReward) {
+	t.Helper()
+
+	assert.Len(t, actual, len(expected))
+
+	for _, groupReward := range actual {
+		assert.Equal(t, expected[groupReward.GroupID], groupReward.Amount.Int64(), "group %d", groupReward.GroupID)
+	}
+}
+
+func TestEpochData_GroupRewards(t *testing.T) {
+	// Rates and rewards are rounded down, so the dust is not distributed.
+	assertGroupRewards(t, map[types.U32]int64{1: 333, 2: 666}, testEpochData.GroupRewards())
+
+	assert.Empty(t, EpochData{Reward: u128(1000)}.GroupRewards())
+	assertGroupRewards(
+		t,
+		map[types.U32]int64{1: 0},
+		EpochData{Reward: u128(1000), Weights: []EpochWeights{{GroupID: 1}}}.GroupRewards(),
+	)
+}
+
+func TestEpochData_Apply(t *testing.T) {
+	data := testEpochData.Apply(EpochChanges{
+		Reward: types.NewOption(u128(2000)),
+		Weights: []EpochWeights{
+			{GroupID: 0, Weight: 5},
+			{GroupID: 2, Weight: 3},
+		},
+	})
+
+	assert.Equal(t, types.U64(100), data.Duration)
+	assert.Equal(t, int64(2000), data.Reward.Int64())
+	assert.Equal(t, []EpochWeights{
+		{GroupID: 0, Weight: 5},
+		{GroupID: 1, Weight: 1},
+		{GroupID: 2, Weight: 3},
+	}, data.Weights)
+
+	// The original configuration is not modified.
+	assert.Equal(t, types.U64(2), testEpochData.Weights[1].Weight)
+}
+
+func TestEpochTracker(t *testing.T) {
+	tracker := NewEpochTracker(testEpochData, 500)
+
+	changes := EpochChanges{
+		Duration: types.NewOption[types.U64](200),
+		Weights:  []EpochWeights{{GroupID: 1, Weight: 3}},
+		Currencies: []EpochCurrencies{
+			{CurrencyID: testCurrencyA, GroupID: 1},
+		},
+	}
+
+	projection := tracker.Project(changes, 3)
+
+	assert.Len(t, projection, 3)
+	assert.Equal(t, types.U64(500), projection[0].At)
+	assertGroupRewards(t, map[types.U32]int64{1: 333, 2: 666}, projection[0].GroupRewards)
+	assert.Equal(t, types.U64(700), projection[1].At)
+	assertGroupRewards(t, map[types.U32]int64{1: 600, 2: 400}, projection[1].GroupRewards)
+	assert.Equal(t, types.U64(900), projection[2].At)
+
+	distribution := tracker.ProcessNewEpoch(EventLiquidityRewardsNewEpoch{
+		EndsOn:      706,
+		Reward:      u128(1000),
+		LastChanges: changes,
+	})
+
+	assert.Equal(t, types.U64(500), distribution.At)
+	assertGroupRewards(t, map[types.U32]int64{1: 333, 2: 666}, distribution.GroupRewards)
+
+	assert.Equal(t, types.U64(706), tracker.EndOfEpoch())
+	assert.Equal(t, types.U64(200), tracker.Active().Duration)
+
+	groupID, ok := tracker.CurrencyGroup(testCurrencyA)
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(1), groupID)
+
+	projection = tracker.Project(EpochChanges{}, 2)
+
+	assert.Equal(t, types.U64(706), projection[0].At)
+	assertGroupRewards(t, map[types.U32]int64{1: 600, 2: 400}, projection[0].GroupRewards)
+	assert.Equal(t, types.U64(906), projection[1].At)
+}
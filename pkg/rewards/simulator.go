@@ -0,0 +1,470 @@
+package rewards
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/chain-custom-types/pkg/phase"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	// rateAccuracy is the accuracy of the runtime's `FixedI128` reward per token.
+	rateAccuracy = big.NewInt(1_000_000_000_000_000_000)
+
+	ErrCurrencyNotAttached = errors.New("currency is not attached to a group")
+	ErrInsufficientStake   = errors.New("insufficient stake")
+	ErrNegativeReward      = errors.New("computed reward is negative")
+	ErrRewardMismatch      = errors.New("claimed reward doesn't match the computed reward")
+)
+
+// simGroup is the `Group` of the base reward mechanism.
+type simGroup struct {
+	totalStake     *big.Int
+	rewardPerToken *big.Int
+}
+
+// simCurrency is the `Currency` of the base reward mechanism. rptChanges holds the differences of reward per token
+// between the groups of each currency movement.
+type simCurrency struct {
+	groupID    types.U32
+	attached   bool
+	totalStake *big.Int
+	rptChanges []*big.Int
+}
+
+// simAccount is the `Account` of the base reward mechanism.
+type simAccount struct {
+	stake                *big.Int
+	rewardTally          *big.Int
+	lastCurrencyMovement int
+}
+
+type accountKey struct {
+	account    types.AccountID
+	currencyID currency.CurrencyID
+}
+
+// Simulator replicates the base reward mechanism of the `rewards` pallet, used by the `LiquidityRewardsBase`
+// instance. All the arithmetic follows the runtime, including the rounding of its `FixedI128` reward per token.
+type Simulator struct {
+	groups     map[types.U32]*simGroup
+	currencies map[currency.CurrencyID]*simCurrency
+	accounts   map[accountKey]*simAccount
+}
+
+func NewSimulator() *Simulator {
+	return &Simulator{
+		groups:     make(map[types.U32]*simGroup),
+		currencies: make(map[currency.CurrencyID]*simCurrency),
+		accounts:   make(map[accountKey]*simAccount),
+	}
+}
+
+func (s *Simulator) group(groupID types.U32) *simGroup {
+	g, ok := s.groups[groupID]
+
+	if !ok {
+		g = &simGroup{
+			totalStake:     new(big.Int),
+			rewardPerToken: new(big.Int),
+		}
+
+		s.groups[groupID] = g
+	}
+
+	return g
+}
+
+func (s *Simulator) currency(currencyID currency.CurrencyID) *simCurrency {
+	c, ok := s.currencies[currencyID]
+
+	if !ok {
+		c = &simCurrency{
+			totalStake: new(big.Int),
+		}
+
+		s.currencies[currencyID] = c
+	}
+
+	return c
+}
+
+func (s *Simulator) account(account types.AccountID, currencyID currency.CurrencyID) *simAccount {
+	key := accountKey{account, currencyID}
+
+	a, ok := s.accounts[key]
+
+	if !ok {
+		a = &simAccount{
+			stake:       new(big.Int),
+			rewardTally: new(big.Int),
+		}
+
+		s.accounts[key] = a
+	}
+
+	return a
+}
+
+func (s *Simulator) attachedCurrency(currencyID currency.CurrencyID) (*simCurrency, *simGroup, error) {
+	c, ok := s.currencies[currencyID]
+
+	if !ok || !c.attached {
+		return nil, nil, fmt.Errorf("%w: %s", ErrCurrencyNotAttached, currencyID)
+	}
+
+	return c, s.group(c.groupID), nil
+}
+
+// AttachCurrency attaches the currency to the group. If the currency was attached to another group,
+// its stake is moved to the new group while keeping the rewards that were earned in the previous group.
+func (s *Simulator) AttachCurrency(currencyID currency.CurrencyID, groupID types.U32) {
+	c := s.currency(currencyID)
+	next := s.group(groupID)
+
+	if c.attached {
+		prev := s.group(c.groupID)
+
+		c.rptChanges = append(c.rptChanges, new(big.Int).Sub(next.rewardPerToken, prev.rewardPerToken))
+
+		prev.totalStake.Sub(prev.totalStake, c.totalStake)
+		next.totalStake.Add(next.totalStake, c.totalStake)
+	}
+
+	c.groupID = groupID
+	c.attached = true
+}
+
+// RewardGroup distributes the amount among the stakers of the group and returns the distributed amount,
+// which is zero if the group has no stake.
+func (s *Simulator) RewardGroup(groupID types.U32, amount types.U128) types.U128 {
+	g := s.group(groupID)
+
+	if g.totalStake.Sign() == 0 {
+		return types.NewU128(*big.NewInt(0))
+	}
+
+	reward := u128Int(amount)
+
+	g.rewardPerToken.Add(g.rewardPerToken, rateFromRational(reward, g.totalStake))
+
+	return types.NewU128(*reward)
+}
+
+// DepositStake adds stake of the currency to the account.
+func (s *Simulator) DepositStake(account types.AccountID, currencyID currency.CurrencyID, amount types.U128) error {
+	c, g, err := s.attachedCurrency(currencyID)
+
+	if err != nil {
+		return err
+	}
+
+	a := s.account(account, currencyID)
+	stake := u128Int(amount)
+
+	a.applyRptChanges(c.rptChanges)
+
+	a.stake.Add(a.stake, stake)
+	a.rewardTally.Add(a.rewardTally, mulInt(g.rewardPerToken, stake))
+
+	g.totalStake.Add(g.totalStake, stake)
+	c.totalStake.Add(c.totalStake, stake)
+
+	return nil
+}
+
+// WithdrawStake removes stake of the currency from the account.
+func (s *Simulator) WithdrawStake(account types.AccountID, currencyID currency.CurrencyID, amount types.U128) error {
+	c, g, err := s.attachedCurrency(currencyID)
+
+	if err != nil {
+		return err
+	}
+
+	a := s.account(account, currencyID)
+	stake := u128Int(amount)
+
+	if a.stake.Cmp(stake) < 0 {
+		return fmt.Errorf("%w: %s staked, %s requested", ErrInsufficientStake, a.stake, stake)
+	}
+
+	a.applyRptChanges(c.rptChanges)
+
+	a.stake.Sub(a.stake, stake)
+	a.rewardTally.Sub(a.rewardTally, mulInt(g.rewardPerToken, stake))
+
+	g.totalStake.Sub(g.totalStake, stake)
+	c.totalStake.Sub(c.totalStake, stake)
+
+	return nil
+}
+
+// ComputeReward returns the reward that the account is able to claim for its stake of the currency.
+func (s *Simulator) ComputeReward(account types.AccountID, currencyID currency.CurrencyID) (types.U128, error) {
+	c, g, err := s.attachedCurrency(currencyID)
+
+	if err != nil {
+		return types.U128{}, err
+	}
+
+	reward, err := s.account(account, currencyID).reward(g, c)
+
+	if err != nil {
+		return types.U128{}, err
+	}
+
+	return types.NewU128(*reward), nil
+}
+
+// ClaimReward returns the claimable reward of the account for its stake of the currency and resets it.
+func (s *Simulator) ClaimReward(account types.AccountID, currencyID currency.CurrencyID) (types.U128, error) {
+	c, g, err := s.attachedCurrency(currencyID)
+
+	if err != nil {
+		return types.U128{}, err
+	}
+
+	a := s.account(account, currencyID)
+
+	reward, err := a.reward(g, c)
+
+	if err != nil {
+		return types.U128{}, err
+	}
+
+	a.applyRptChanges(c.rptChanges)
+
+	a.rewardTally = mulInt(g.rewardPerToken, a.stake)
+
+	return types.NewU128(*reward), nil
+}
+
+// Stake returns the stake of the currency of the account.
+func (s *Simulator) Stake(account types.AccountID, currencyID currency.CurrencyID) types.U128 {
+	a, ok := s.accounts[accountKey{account, currencyID}]
+
+	if !ok {
+		return types.NewU128(*big.NewInt(0))
+	}
+
+	return types.NewU128(*new(big.Int).Set(a.stake))
+}
+
+// CurrencyStake returns the total stake of the currency.
+func (s *Simulator) CurrencyStake(currencyID currency.CurrencyID) types.U128 {
+	c, ok := s.currencies[currencyID]
+
+	if !ok {
+		return types.NewU128(*big.NewInt(0))
+	}
+
+	return types.NewU128(*new(big.Int).Set(c.totalStake))
+}
+
+// GroupStake returns the total stake of the currencies attached to the group.
+func (s *Simulator) GroupStake(groupID types.U32) types.U128 {
+	g, ok := s.groups[groupID]
+
+	if !ok {
+		return types.NewU128(*big.NewInt(0))
+	}
+
+	return types.NewU128(*new(big.Int).Set(g.totalStake))
+}
+
+// CurrencyGroup returns the group to which the currency is attached.
+func (s *Simulator) CurrencyGroup(currencyID currency.CurrencyID) (types.U32, bool) {
+	c, ok := s.currencies[currencyID]
+
+	if !ok || !c.attached {
+		return 0, false
+	}
+
+	return c.groupID, true
+}
+
+// ProcessBlock applies the `LiquidityRewardsBase` events of a block in the order of their phase.
+// Blocks must be processed in order, starting with the first block in which the pallet emitted events.
+//
+// Since the events are grouped per type, events of the same phase are applied as group rewards,
+// currency attachments, deposits, withdrawals and claims. The first two match the initialization of
+// `liquidity-rewards`, which distributes the reward of the epoch before applying the currency changes.
+// The amount of each `RewardClaimed` event is checked against the computed reward, ErrRewardMismatch is returned
+// if they differ. If an event fails to apply, none of the events of the block are applied. Events that belong to the same extrinsic in another order
+// should be applied with the individual methods instead.
+func (s *Simulator) ProcessBlock(
+	attached []EventLiquidityRewardsBaseCurrencyAttached,
+	rewarded []EventLiquidityRewardsBaseGroupRewarded,
+	deposited []EventLiquidityRewardsBaseStakeDeposited,
+	withdrawn []EventLiquidityRewardsBaseStakeWithdrawn,
+	claimed []EventLiquidityRewardsBaseRewardClaimed,
+) error {
+	// The block is applied to a copy, which replaces the state only if all the events apply.
+	next := s.clone()
+
+	evts := make([]phase.Event, 0, len(attached)+len(rewarded)+len(deposited)+len(withdrawn)+len(claimed))
+
+	for _, event := range rewarded {
+		event := event
+
+		evts = append(evts, phase.Event{Phase: event.Phase, Apply: func() error {
+			next.RewardGroup(event.GroupID, event.Amount)
+
+			return nil
+		}})
+	}
+
+	for _, event := range attached {
+		event := event
+
+		evts = append(evts, phase.Event{Phase: event.Phase, Apply: func() error {
+			next.AttachCurrency(event.CurrencyID, event.To)
+
+			return nil
+		}})
+	}
+
+	for _, event := range deposited {
+		event := event
+
+		evts = append(evts, phase.Event{Phase: event.Phase, Apply: func() error {
+			return next.DepositStake(event.AccountID, event.CurrencyID, event.Amount)
+		}})
+	}
+
+	for _, event := range withdrawn {
+		event := event
+
+		evts = append(evts, phase.Event{Phase: event.Phase, Apply: func() error {
+			return next.WithdrawStake(event.AccountID, event.CurrencyID, event.Amount)
+		}})
+	}
+
+	for _, event := range claimed {
+		event := event
+
+		evts = append(evts, phase.Event{Phase: event.Phase, Apply: func() error {
+			reward, err := next.ComputeReward(event.AccountID, event.CurrencyID)
+
+			if err != nil {
+				return err
+			}
+
+			if reward.Cmp(u128Int(event.Amount)) != 0 {
+				return fmt.Errorf("%w: computed %s, claimed %s", ErrRewardMismatch, reward, u128Int(event.Amount))
+			}
+
+			_, err = next.ClaimReward(event.AccountID, event.CurrencyID)
+
+			return err
+		}})
+	}
+
+	if err := phase.Apply(evts); err != nil {
+		return err
+	}
+
+	*s = *next
+
+	return nil
+}
+
+// clone returns a deep copy of the simulator.
+func (s *Simulator) clone() *Simulator {
+	res := &Simulator{
+		groups:     make(map[types.U32]*simGroup, len(s.groups)),
+		currencies: make(map[currency.CurrencyID]*simCurrency, len(s.currencies)),
+		accounts:   make(map[accountKey]*simAccount, len(s.accounts)),
+	}
+
+	for groupID, g := range s.groups {
+		res.groups[groupID] = &simGroup{
+			totalStake:     new(big.Int).Set(g.totalStake),
+			rewardPerToken: new(big.Int).Set(g.rewardPerToken),
+		}
+	}
+
+	for currencyID, c := range s.currencies {
+		rptChanges := make([]*big.Int, len(c.rptChanges))
+
+		for i, rptChange := range c.rptChanges {
+			rptChanges[i] = new(big.Int).Set(rptChange)
+		}
+
+		res.currencies[currencyID] = &simCurrency{
+			groupID:    c.groupID,
+			attached:   c.attached,
+			totalStake: new(big.Int).Set(c.totalStake),
+			rptChanges: rptChanges,
+		}
+	}
+
+	for key, a := range s.accounts {
+		res.accounts[key] = &simAccount{
+			stake:                new(big.Int).Set(a.stake),
+			rewardTally:          new(big.Int).Set(a.rewardTally),
+			lastCurrencyMovement: a.lastCurrencyMovement,
+		}
+	}
+
+	return res
+}
+
+// applyRptChanges accounts for the currency movements that happened since the last update of the account,
+// so that the rewards earned in previous groups are kept.
+func (a *simAccount) applyRptChanges(rptChanges []*big.Int) {
+	a.rewardTally.Add(a.rewardTally, a.rptChangesTally(rptChanges))
+
+	a.lastCurrencyMovement = len(rptChanges)
+}
+
+// rptChangesTally sums the rpt changes before multiplying by the stake, like `get_tally_from_rpt_changes`,
+// so that the rounding matches the runtime.
+func (a *simAccount) rptChangesTally(rptChanges []*big.Int) *big.Int {
+	rptToAdd := new(big.Int)
+
+	for _, rptChange := range rptChanges[a.lastCurrencyMovement:] {
+		rptToAdd.Add(rptToAdd, rptChange)
+	}
+
+	return mulInt(rptToAdd, a.stake)
+}
+
+func (a *simAccount) reward(g *simGroup, c *simCurrency) (*big.Int, error) {
+	reward := mulInt(g.rewardPerToken, a.stake)
+
+	reward.Sub(reward, a.rewardTally)
+	reward.Sub(reward, a.rptChangesTally(c.rptChanges))
+
+	if reward.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNegativeReward, reward)
+	}
+
+	return reward, nil
+}
+
+// mulInt multiplies the integer by the fixed point rate, rounding towards zero like `FixedI128::checked_mul_int`.
+func mulInt(rate, n *big.Int) *big.Int {
+	res := new(big.Int).Mul(rate, n)
+
+	return res.Quo(res, rateAccuracy)
+}
+
+// rateFromRational returns n / d as a fixed point rate, rounding towards zero
+// like `FixedI128::checked_from_rational`.
+func rateFromRational(n, d *big.Int) *big.Int {
+	res := new(big.Int).Mul(n, rateAccuracy)
+
+	return res.Quo(res, d)
+}
+
+func u128Int(v types.U128) *big.Int {
+	if v.Int == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(v.Int)
+}
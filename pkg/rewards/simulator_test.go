@@ -0,0 +1,226 @@
+package rewards
+
+import (
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertReward(t *testing.T, s *Simulator, account types.AccountID, currencyID currency.CurrencyID, expected int64) {
+	t.Helper()
+
+	reward, err := s.ComputeReward(account, currencyID)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, reward.Int64())
+}
+
+func TestSimulator_CurrencyMovement(t *testing.T) {
+	s := NewSimulator()
+
+	s.AttachCurrency(testCurrencyA, 1)
+	s.AttachCurrency(testCurrencyB, 2)
+
+	assert.NoError(t, s.DepositStake(testAlice, testCurrencyA, u128(100)))
+	assert.NoError(t, s.DepositStake(testBob, testCurrencyA, u128(300)))
+
+	assert.Equal(t, int64(1000), s.RewardGroup(1, u128(1000)).Int64())
+
+	assertReward(t, s, testAlice, testCurrencyA, 250)
+	assertReward(t, s, testBob, testCurrencyA, 750)
+
+	assert.NoError(t, s.DepositStake(testBob, testCurrencyB, u128(100)))
+
+	s.RewardGroup(2, u128(500))
+
+	// Moving the currency keeps the rewards earned in the previous group.
+	s.AttachCurrency(testCurrencyA, 2)
+
+	assert.Equal(t, int64(0), s.GroupStake(1).Int64())
+	assert.Equal(t, int64(500), s.GroupStake(2).Int64())
+
+	groupID, ok := s.CurrencyGroup(testCurrencyA)
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(2), groupID)
+
+	assertReward(t, s, testAlice, testCurrencyA, 250)
+
+	s.RewardGroup(2, u128(1000))
+
+	assertReward(t, s, testAlice, testCurrencyA, 450)
+	assertReward(t, s, testBob, testCurrencyA, 1350)
+	assertReward(t, s, testBob, testCurrencyB, 700)
+
+	reward, err := s.ClaimReward(testBob, testCurrencyA)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1350), reward.Int64())
+
+	assertReward(t, s, testBob, testCurrencyA, 0)
+
+	assert.NoError(t, s.WithdrawStake(testAlice, testCurrencyA, u128(100)))
+
+	// Withdrawing keeps the earned rewards.
+	assertReward(t, s, testAlice, testCurrencyA, 450)
+	assert.Equal(t, int64(0), s.Stake(testAlice, testCurrencyA).Int64())
+	assert.Equal(t, int64(300), s.CurrencyStake(testCurrencyA).Int64())
+
+	s.RewardGroup(2, u128(400))
+
+	assertReward(t, s, testAlice, testCurrencyA, 450)
+	assertReward(t, s, testBob, testCurrencyA, 300)
+	assertReward(t, s, testBob, testCurrencyB, 800)
+}
+
+func TestSimulator_Rounding(t *testing.T) {
+	s := NewSimulator()
+
+	s.AttachCurrency(testCurrencyA, 1)
+
+	for i := byte(1); i <= 3; i++ {
+		assert.NoError(t, s.DepositStake(types.AccountID{i}, testCurrencyA, u128(1)))
+	}
+
+	s.RewardGroup(1, u128(10))
+
+	// The reward per token is 3.333..., which rounds every reward down.
+	for i := byte(1); i <= 3; i++ {
+		assertReward(t, s, types.AccountID{i}, testCurrencyA, 3)
+	}
+
+	// Nothing is distributed if there's no stake.
+	assert.Equal(t, int64(0), s.RewardGroup(2, u128(10)).Int64())
+}
+
+func TestSimulator_RptChangesRounding(t *testing.T) {
+	s := NewSimulator()
+
+	testCurrencyC := currency.NewForeignAssetCurrencyID(3)
+
+	s.AttachCurrency(testCurrencyA, 1)
+	s.AttachCurrency(testCurrencyB, 2)
+	s.AttachCurrency(testCurrencyC, 3)
+
+	assert.NoError(t, s.DepositStake(testAlice, testCurrencyA, u128(3)))
+	assert.NoError(t, s.DepositStake(testBob, testCurrencyB, u128(3)))
+	assert.NoError(t, s.DepositStake(testBob, testCurrencyC, u128(3)))
+
+	// The reward per token of the groups is 0, 0.333... and 0.666...
+	s.RewardGroup(2, u128(1))
+	s.RewardGroup(3, u128(2))
+
+	// Both movements have an rpt change of 0.333..., which are summed before being multiplied by the stake.
+	s.AttachCurrency(testCurrencyA, 2)
+	s.AttachCurrency(testCurrencyA, 3)
+
+	assertReward(t, s, testAlice, testCurrencyA, 0)
+}
+
+func TestSimulator_Errors(t *testing.T) {
+	s := NewSimulator()
+
+	assert.ErrorIs(t, s.DepositStake(testAlice, testCurrencyA, u128(100)), ErrCurrencyNotAttached)
+
+	_, err := s.ComputeReward(testAlice, testCurrencyA)
+	assert.ErrorIs(t, err, ErrCurrencyNotAttached)
+
+	s.AttachCurrency(testCurrencyA, 1)
+
+	assert.NoError(t, s.DepositStake(testAlice, testCurrencyA, u128(100)))
+	assert.ErrorIs(t, s.WithdrawStake(testAlice, testCurrencyA, u128(101)), ErrInsufficientStake)
+}
+
+func TestSimulator_ProcessBlock(t *testing.T) {
+	s := NewSimulator()
+
+	extrinsic := func(i uint32) types.Phase {
+		return types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: i}
+	}
+
+	err := s.ProcessBlock(
+		[]EventLiquidityRewardsBaseCurrencyAttached{
+			{Phase: types.Phase{IsInitialization: true}, CurrencyID: testCurrencyA, To: 1},
+		},
+		nil,
+		[]EventLiquidityRewardsBaseStakeDeposited{
+			{Phase: extrinsic(1), GroupID: 1, CurrencyID: testCurrencyA, AccountID: testAlice, Amount: u128(100)},
+			{Phase: extrinsic(2), GroupID: 1, CurrencyID: testCurrencyA, AccountID: testBob, Amount: u128(100)},
+		},
+		nil,
+		nil,
+	)
+	assert.NoError(t, err)
+
+	err = s.ProcessBlock(
+		nil,
+		[]EventLiquidityRewardsBaseGroupRewarded{
+			{Phase: types.Phase{IsInitialization: true}, GroupID: 1, Amount: u128(1000)},
+		},
+		nil,
+		[]EventLiquidityRewardsBaseStakeWithdrawn{
+			{Phase: extrinsic(1), GroupID: 1, CurrencyID: testCurrencyA, AccountID: testAlice, Amount: u128(50)},
+		},
+		[]EventLiquidityRewardsBaseRewardClaimed{
+			{Phase: extrinsic(2), GroupID: 1, CurrencyID: testCurrencyA, AccountID: testAlice, Amount: u128(500)},
+		},
+	)
+	assert.NoError(t, err)
+
+	err = s.ProcessBlock(
+		nil,
+		[]EventLiquidityRewardsBaseGroupRewarded{
+			{Phase: types.Phase{IsInitialization: true}, GroupID: 1, Amount: u128(300)},
+		},
+		nil,
+		[]EventLiquidityRewardsBaseStakeWithdrawn{
+			{Phase: extrinsic(1), GroupID: 1, CurrencyID: testCurrencyA, AccountID: testBob, Amount: u128(100)},
+		},
+		[]EventLiquidityRewardsBaseRewardClaimed{
+			{Phase: extrinsic(2), GroupID: 1, CurrencyID: testCurrencyA, AccountID: testBob, Amount: u128(499)},
+		},
+	)
+	assert.ErrorIs(t, err, ErrRewardMismatch)
+
+	// None of the events of the block are applied when one of them fails.
+	assertReward(t, s, testBob, testCurrencyA, 500)
+	assert.Equal(t, int64(100), s.Stake(testBob, testCurrencyA).Int64())
+	assert.Equal(t, int64(150), s.CurrencyStake(testCurrencyA).Int64())
+	assert.Equal(t, int64(150), s.GroupStake(1).Int64())
+}
+
+func TestSimulator_ProcessBlock_EpochBoundary(t *testing.T) {
+	s := NewSimulator()
+
+	s.AttachCurrency(testCurrencyA, 1)
+	s.AttachCurrency(testCurrencyB, 2)
+
+	assert.NoError(t, s.DepositStake(testAlice, testCurrencyA, u128(100)))
+	assert.NoError(t, s.DepositStake(testBob, testCurrencyB, u128(100)))
+
+	initialization := types.Phase{IsInitialization: true}
+
+	// The epoch reward is distributed before the currency is moved to the rewarded group.
+	err := s.ProcessBlock(
+		[]EventLiquidityRewardsBaseCurrencyAttached{
+			{Phase: initialization, CurrencyID: testCurrencyB, From: types.NewOption(types.U32(2)), To: 1},
+		},
+		[]EventLiquidityRewardsBaseGroupRewarded{
+			{Phase: initialization, GroupID: 1, Amount: u128(1000)},
+		},
+		nil,
+		nil,
+		[]EventLiquidityRewardsBaseRewardClaimed{
+			{
+				Phase:      types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1},
+				GroupID:    1,
+				CurrencyID: testCurrencyA,
+				AccountID:  testAlice,
+				Amount:     u128(1000),
+			},
+		},
+	)
+	assert.NoError(t, err)
+
+	assertReward(t, s, testBob, testCurrencyB, 0)
+	assert.Equal(t, int64(200), s.GroupStake(1).Int64())
+}
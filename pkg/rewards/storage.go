@@ -0,0 +1,251 @@
+package rewards
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/xxhash"
+)
+
+// These are the storage items of the `liquidity-rewards` pallet and of its `rewards` pallet instance.
+
+// Group is the value of the `Groups` storage of the base reward mechanism.
+type Group struct {
+	TotalStake types.U128
+	// RewardPerToken is the raw value of the `FixedI128` reward per token, with an accuracy of 1e18.
+	RewardPerToken types.I128
+}
+
+// Currency is the currency state of the base reward mechanism.
+type Currency struct {
+	TotalStake types.U128
+	// RptChanges holds the raw `FixedI128` differences of reward per token between the groups
+	// of each currency movement.
+	RptChanges []types.I128
+}
+
+// RewardCurrency is the value of the `Currencies` storage, it's not attached to a group if GroupID is not set.
+type RewardCurrency struct {
+	GroupID types.Option[types.U32]
+	Base    Currency
+}
+
+// StakeAccount is the value of the `StakeAccounts` storage, which holds the stake of an account in a currency.
+type StakeAccount struct {
+	Stake types.U128
+	// RewardTally is the signed `IBalance` tally of the account, it can be negative.
+	RewardTally          types.I128
+	LastCurrencyMovement types.U32
+}
+
+const (
+	liquidityRewardsPrefix     = "LiquidityRewards"
+	liquidityRewardsBasePrefix = "LiquidityRewardsBase"
+
+	groupsMethodName           = "Groups"
+	currenciesMethodName       = "Currencies"
+	stakeAccountsMethodName    = "StakeAccounts"
+	activeEpochDataMethodName  = "ActiveEpochData"
+	nextEpochChangesMethodName = "NextEpochChanges"
+	endOfEpochMethodName       = "EndOfEpoch"
+
+	// blake2_128Len is the length of the hash that precedes each key of a `Blake2_128Concat` map.
+	blake2_128Len = 16
+)
+
+var (
+	ErrInvalidStorageKey = errors.New("invalid storage key")
+)
+
+// GroupsStorageKey returns the storage key of a group.
+func GroupsStorageKey(meta *types.Metadata, groupID types.U32) (types.StorageKey, error) {
+	encodedGroupID, err := codec.Encode(groupID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreateStorageKey(meta, liquidityRewardsBasePrefix, groupsMethodName, encodedGroupID)
+}
+
+// CurrenciesStorageKey returns the storage key of a currency.
+func CurrenciesStorageKey(meta *types.Metadata, currencyID types.CurrencyID) (types.StorageKey, error) {
+	encodedCurrencyID, err := encodeCurrencyID(currencyID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreateStorageKey(meta, liquidityRewardsBasePrefix, currenciesMethodName, encodedCurrencyID)
+}
+
+// StakeAccountsStorageKey returns the storage key of the stake of an account in a currency.
+func StakeAccountsStorageKey(
+	meta *types.Metadata,
+	account types.AccountID,
+	currencyID types.CurrencyID,
+) (types.StorageKey, error) {
+	encodedAccount, err := codec.Encode(account)
+
+	if err != nil {
+		return nil, err
+	}
+
+	encodedCurrencyID, err := encodeCurrencyID(currencyID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreateStorageKey(
+		meta,
+		liquidityRewardsBasePrefix,
+		stakeAccountsMethodName,
+		encodedAccount,
+		encodedCurrencyID,
+	)
+}
+
+// ActiveEpochDataStorageKey returns the storage key of the configuration of the active epoch, see EpochData.
+func ActiveEpochDataStorageKey(meta *types.Metadata) (types.StorageKey, error) {
+	return types.CreateStorageKey(meta, liquidityRewardsPrefix, activeEpochDataMethodName)
+}
+
+// NextEpochChangesStorageKey returns the storage key of the changes applied when the active epoch ends,
+// see EpochChanges.
+func NextEpochChangesStorageKey(meta *types.Metadata) (types.StorageKey, error) {
+	return types.CreateStorageKey(meta, liquidityRewardsPrefix, nextEpochChangesMethodName)
+}
+
+// EndOfEpochStorageKey returns the storage key of the moment at which the active epoch ends, stored as a types.U64.
+func EndOfEpochStorageKey(meta *types.Metadata) (types.StorageKey, error) {
+	return types.CreateStorageKey(meta, liquidityRewardsPrefix, endOfEpochMethodName)
+}
+
+// DecodeGroupsStorageKey returns the group ID of a storage key of the `Groups` map.
+func DecodeGroupsStorageKey(key types.StorageKey) (types.U32, error) {
+	var groupID types.U32
+
+	rest, err := decodeMapKey(key, groupsMethodName, &groupID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rest) != 0 {
+		return 0, fmt.Errorf("%w: trailing bytes", ErrInvalidStorageKey)
+	}
+
+	return groupID, nil
+}
+
+// The storage keys are built from currency IDs of GSRPC, like the calls, but are decoded into a currency.CurrencyID
+// since keys of currencies that GSRPC doesn't support, such as `LocalAsset`, can be stored by the runtime.
+
+// DecodeCurrenciesStorageKey returns the currency ID of a storage key of the `Currencies` map.
+func DecodeCurrenciesStorageKey(key types.StorageKey) (currency.CurrencyID, error) {
+	var currencyID currency.CurrencyID
+
+	rest, err := decodeMapKey(key, currenciesMethodName, &currencyID)
+
+	if err != nil {
+		return currency.CurrencyID{}, err
+	}
+
+	if len(rest) != 0 {
+		return currency.CurrencyID{}, fmt.Errorf("%w: trailing bytes", ErrInvalidStorageKey)
+	}
+
+	return currencyID, nil
+}
+
+// DecodeStakeAccountsStorageKey returns the account and currency ID of a storage key of the `StakeAccounts` map,
+// for example one returned when iterating over the stakes of an account.
+func DecodeStakeAccountsStorageKey(key types.StorageKey) (types.AccountID, currency.CurrencyID, error) {
+	var (
+		account    types.AccountID
+		currencyID currency.CurrencyID
+	)
+
+	rest, err := decodeMapKey(key, stakeAccountsMethodName, &account)
+
+	if err != nil {
+		return types.AccountID{}, currency.CurrencyID{}, err
+	}
+
+	rest, err = decodeBlake2_128ConcatKey(rest, &currencyID)
+
+	if err != nil {
+		return types.AccountID{}, currency.CurrencyID{}, err
+	}
+
+	if len(rest) != 0 {
+		return types.AccountID{}, currency.CurrencyID{}, fmt.Errorf("%w: trailing bytes", ErrInvalidStorageKey)
+	}
+
+	return account, currencyID, nil
+}
+
+// encodeCurrencyID encodes the currency ID of GSRPC like the runtime's currency.CurrencyID.
+func encodeCurrencyID(currencyID types.CurrencyID) ([]byte, error) {
+	runtimeCurrencyID, err := currency.FromGSRPC(currencyID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.Encode(runtimeCurrencyID)
+}
+
+// decodeMapKey checks the prefix of a map key of the `LiquidityRewardsBase` pallet and decodes its first key.
+func decodeMapKey(key types.StorageKey, method string, target any) ([]byte, error) {
+	prefix := append(
+		xxhash.New128([]byte(liquidityRewardsBasePrefix)).Sum(nil),
+		xxhash.New128([]byte(method)).Sum(nil)...,
+	)
+
+	if !bytes.HasPrefix(key, prefix) {
+		return nil, fmt.Errorf("%w: not a %s %s key", ErrInvalidStorageKey, liquidityRewardsBasePrefix, method)
+	}
+
+	return decodeBlake2_128ConcatKey(key[len(prefix):], target)
+}
+
+// decodeBlake2_128ConcatKey decodes a `Blake2_128Concat` key into the target and returns the remaining bytes.
+func decodeBlake2_128ConcatKey(b []byte, target any) ([]byte, error) {
+	if len(b) < blake2_128Len {
+		return nil, fmt.Errorf("%w: missing key hash", ErrInvalidStorageKey)
+	}
+
+	keyHash, rest := b[:blake2_128Len], b[blake2_128Len:]
+
+	reader := bytes.NewReader(rest)
+
+	if err := scale.NewDecoder(reader).Decode(target); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStorageKey, err)
+	}
+
+	encodedKey := rest[:len(rest)-reader.Len()]
+
+	hasher, err := hash.NewBlake2b128(nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := hasher.Write(encodedKey); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(hasher.Sum(nil), keyHash) {
+		return nil, fmt.Errorf("%w: key hash mismatch", ErrInvalidStorageKey)
+	}
+
+	return rest[len(encodedKey):], nil
+}
@@ -0,0 +1,175 @@
+package rewards
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/chain-custom-types/pkg/currency"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+)
+
+func TestStorageValues_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[Group](t, 1000)
+	AssertDecodeNilData[Group](t)
+
+	AssertRoundTripFuzz[RewardCurrency](t, 1000)
+	AssertDecodeNilData[RewardCurrency](t)
+
+	AssertRoundTripFuzz[StakeAccount](t, 1000)
+	AssertDecodeNilData[StakeAccount](t)
+}
+
+func TestStakeAccount_Decode(t *testing.T) {
+	var account StakeAccount
+
+	// A stake of 100 with a reward tally of -1.
+	err := codec.Decode(
+		codec.MustHexDecodeString(
+			"0x64000000000000000000000000000000"+"ffffffffffffffffffffffffffffffff"+"02000000",
+		),
+		&account,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), account.Stake.Int64())
+	assert.Equal(t, int64(-1), account.RewardTally.Int64())
+	assert.Equal(t, types.U32(2), account.LastCurrencyMovement)
+}
+
+func TestEpochData_Decode(t *testing.T) {
+	var data EpochData
+
+	err := codec.Decode(
+		codec.MustHexDecodeString(
+			"0x8051010000000000"+"64000000000000000000000000000000"+"08"+
+				"01000000"+"0100000000000000"+"02000000"+"0300000000000000",
+		),
+		&data,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, EpochData{
+		Duration: 86400,
+		Reward:   types.NewU128(*big.NewInt(100)),
+		Weights: []EpochWeights{
+			{GroupID: 1, Weight: 1},
+			{GroupID: 2, Weight: 3},
+		},
+	}, data)
+}
+
+func TestStakeAccountsStorageKey(t *testing.T) {
+	key, err := StakeAccountsStorageKey(testMeta, testAlice, testGSRPCCurrencyA)
+	assert.NoError(t, err)
+
+	// Twox128("LiquidityRewardsBase") ++ Twox128("StakeAccounts"), followed by the Blake2_128Concat account
+	// and currency ID.
+	assert.Equal(
+		t,
+		"0x58d9f6b0226789fadef9c54196fceea26be33df442cafd5179dfd7effc4aa15d",
+		codec.HexEncodeToString(key[:32]),
+	)
+	assert.Equal(t, testAlice[:], []byte(key[48:80]))
+	assert.Len(t, key, 32+16+32+16+5)
+
+	account, currencyID, err := DecodeStakeAccountsStorageKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, testAlice, account)
+	assert.Equal(t, testCurrencyA, currencyID)
+
+	_, _, err = DecodeStakeAccountsStorageKey(append(key, 0))
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+
+	key[len(key)-4] = 2
+
+	_, _, err = DecodeStakeAccountsStorageKey(key)
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+
+	groupKey, err := GroupsStorageKey(testMeta, 1)
+	assert.NoError(t, err)
+
+	_, _, err = DecodeStakeAccountsStorageKey(groupKey)
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+
+	_, _, err = DecodeStakeAccountsStorageKey(key[:40])
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+}
+
+func TestGroupsStorageKey(t *testing.T) {
+	key, err := GroupsStorageKey(testMeta, 7)
+	assert.NoError(t, err)
+	assert.Len(t, key, 32+16+4)
+
+	groupID, err := DecodeGroupsStorageKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(7), groupID)
+
+	_, err = DecodeGroupsStorageKey(append(key, 0))
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+}
+
+func TestCurrenciesStorageKey(t *testing.T) {
+	key, err := CurrenciesStorageKey(testMeta, testGSRPCCurrencyB)
+	assert.NoError(t, err)
+	assert.Len(t, key, 32+16+5)
+
+	currencyID, err := DecodeCurrenciesStorageKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, testCurrencyB, currencyID)
+
+	_, err = DecodeCurrenciesStorageKey(append(key, 0))
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+
+	_, err = DecodeGroupsStorageKey(key)
+	assert.ErrorIs(t, err, ErrInvalidStorageKey)
+
+	// Keys of currencies that GSRPC doesn't support are decoded as well.
+	localAsset := currency.NewLocalAssetCurrencyID(3)
+
+	encodedLocalAsset, err := codec.Encode(localAsset)
+	assert.NoError(t, err)
+
+	key, err = types.CreateStorageKey(testMeta, "LiquidityRewardsBase", "Currencies", encodedLocalAsset)
+	assert.NoError(t, err)
+
+	currencyID, err = DecodeCurrenciesStorageKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, localAsset, currencyID)
+
+	encodedAccount, err := codec.Encode(testAlice)
+	assert.NoError(t, err)
+
+	key, err = types.CreateStorageKey(
+		testMeta,
+		"LiquidityRewardsBase",
+		"StakeAccounts",
+		encodedAccount,
+		encodedLocalAsset,
+	)
+	assert.NoError(t, err)
+
+	account, currencyID, err := DecodeStakeAccountsStorageKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, testAlice, account)
+	assert.Equal(t, localAsset, currencyID)
+}
+
+func TestEpochStorageKeys(t *testing.T) {
+	key, err := EndOfEpochStorageKey(testMeta)
+	assert.NoError(t, err)
+	assert.Equal(t, types.StorageKey(codec.MustHexDecodeString(
+		"0x9712ee05163fd14fbe7c3f6815cfb8b8d4233132aeea7d7202b3170126b46e11",
+	)), key)
+
+	activeKey, err := ActiveEpochDataStorageKey(testMeta)
+	assert.NoError(t, err)
+	assert.Equal(t, key[:16], activeKey[:16])
+	assert.NotEqual(t, key, activeKey)
+
+	nextKey, err := NextEpochChangesStorageKey(testMeta)
+	assert.NoError(t, err)
+	assert.Equal(t, key[:16], nextKey[:16])
+	assert.NotEqual(t, activeKey, nextKey)
+}